@@ -0,0 +1,207 @@
+// cmd/cli.go
+package main
+
+import (
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	configimpl "GWatch/internal/infra/config"
+	"GWatch/internal/infra/logger"
+	"GWatch/internal/infra/monitoring/templates"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// initOneShot 为单次执行的命令初始化应用，日志输出到 stderr
+func initOneShot(opts cliOptions) (*App, bool) {
+	logger.InitStderrLog(opts.logLevel)
+	app, err := InitializeOneShotApp(ConfigPath(opts.configPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "初始化应用程序失败: %v\n", err)
+		return nil, false
+	}
+	logger.InitStderrLog(opts.effectiveLogLevel(app))
+	return app, true
+}
+
+// onceReport once 命令的 JSON 输出
+type onceReport struct {
+	Timestamp time.Time             `json:"timestamp"`
+	Metrics   *entity.SystemMetrics `json:"metrics"`
+	Errors    map[string]string     `json:"errors,omitempty"` // 采集错误（error 类型无法直接序列化，单独列出）
+	Alerts    []onceAlert           `json:"alerts"`
+}
+
+type onceAlert struct {
+	Type      entity.AlertType `json:"type"`
+	Target    string           `json:"target,omitempty"`
+	Severity  string           `json:"severity"`
+	Message   string           `json:"message"`
+	Escalated bool             `json:"escalated,omitempty"`
+	Flapping  bool             `json:"flapping,omitempty"`
+}
+
+// runOnce 采集、判断一次并输出快照
+func runOnce(opts cliOptions, args []string) int {
+	fs := flag.NewFlagSet("once", flag.ExitOnError)
+	format := fs.String("format", "text", "输出格式: text/json")
+	fs.Parse(args)
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "不支持的输出格式 %q，仅支持 text/json\n", *format)
+		return 2
+	}
+
+	app, ok := initOneShot(opts)
+	if !ok {
+		return 1
+	}
+	metrics, alerts, text := app.Monitor.Snapshot(app.Config())
+
+	if *format == "text" {
+		fmt.Println(text)
+		return 0
+	}
+
+	report := onceReport{
+		Timestamp: metrics.Timestamp,
+		Metrics:   metrics,
+		Errors:    metricErrors(metrics),
+		Alerts:    toOnceAlerts(alerts),
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "输出 JSON 失败: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func toOnceAlerts(alerts []monitoring.TriggeredAlert) []onceAlert {
+	res := make([]onceAlert, 0, len(alerts))
+	for _, a := range alerts {
+		res = append(res, onceAlert{Type: a.Type, Target: a.Target, Severity: a.Severity, Message: a.Message,
+			Escalated: a.Escalated, Flapping: a.Flapping})
+	}
+	return res
+}
+
+// metricErrors 收集快照中的采集错误，key 为指标名
+func metricErrors(m *entity.SystemMetrics) map[string]string {
+	errs := map[string]string{}
+	add := func(key string, err error) {
+		if err != nil {
+			errs[key] = err.Error()
+		}
+	}
+	add("cpu", m.CPU.Error)
+	add("memory", m.Memory.Error)
+	add("disk", m.Disk.Error)
+	add("disk.mounts", m.Disk.MountsError)
+	add("network", m.Network.Error)
+	add("network.interfaces", m.Network.InterfacesError)
+	for _, r := range m.Redis {
+		key := "redis"
+		if r.Name != "" {
+			key += "." + r.Name
+		}
+		add(key, r.ConnectionError)
+		add(key+".detail", r.DetailError)
+	}
+	for _, r := range m.MySQL {
+		key := "mysql"
+		if r.Name != "" {
+			key += "." + r.Name
+		}
+		add(key, r.Error)
+	}
+	add("http", m.HTTP.Error)
+	for _, itf := range m.HTTP.Interfaces {
+		add("http."+itf.Name, itf.Error)
+	}
+	return errs
+}
+
+// runTestNotify 发送一条测试告警，用于验证通知渠道配置
+func runTestNotify(opts cliOptions) int {
+	app, ok := initOneShot(opts)
+	if !ok {
+		return 1
+	}
+	if len(app.Config().NotificationChannels()) == 0 {
+		fmt.Fprintln(os.Stderr, "发送测试通知失败: 没有已启用的通知渠道")
+		return 1
+	}
+	hostName, _ := os.Hostname()
+	title := "GWatch 测试通知"
+	markdown := fmt.Sprintf("### %s\n\n- 主机: %s\n- 时间: %s\n- 说明: 这是一条测试消息，收到即表示通知渠道配置正确\n",
+		title, hostName, time.Now().Format(time.DateTime))
+	if err := app.Notifier.Send(title, markdown); err != nil {
+		fmt.Fprintf(os.Stderr, "发送测试通知失败: %v\n", err)
+		return 1
+	}
+	fmt.Println("测试通知已发送")
+	return 0
+}
+
+// runPushNow 立即执行一次全局定时推送（不等待推送时间点）
+func runPushNow(opts cliOptions) int {
+	app, ok := initOneShot(opts)
+	if !ok {
+		return 1
+	}
+	if err := app.ScheduledPushUseCase.RunScheduledPush(app.Config()); err != nil {
+		fmt.Fprintf(os.Stderr, "执行全局定时推送失败: %v\n", err)
+		return 1
+	}
+	fmt.Println("全局定时推送已执行")
+	return 0
+}
+
+// runCheckConfig 校验配置文件并输出所有问题，返回进程退出码（有错误时非0，便于部署前检查）
+func runCheckConfig(opts cliOptions, args []string) int {
+	path := opts.configPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if _, err := configimpl.LoadConfig(path); err != nil {
+		fmt.Fprintf(os.Stderr, "检查配置文件 %s 未通过\n%v\n", path, err)
+		return 1
+	}
+	fmt.Printf("配置文件 %s 校验通过\n", path)
+	return 0
+}
+
+// runPrintConfig 输出实际生效的配置（合并 include 目录、展开变量、填充默认值后），敏感信息脱敏
+func runPrintConfig(opts cliOptions) int {
+	cfg, err := configimpl.LoadConfig(opts.configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "加载配置文件 %s 失败: %v\n", opts.configPath, err)
+		return 1
+	}
+	out, err := configimpl.RedactedYAML(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	fmt.Print(string(out))
+	return 0
+}
+
+// runPrintTemplate 输出内置消息模板
+func runPrintTemplate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "用法: gwatch print-template <%s|%s|%s|%s>\n",
+			templates.Alert, templates.Recovery, templates.TickerReport, templates.ScheduledReport)
+		return 2
+	}
+	text, err := templates.Default(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 2
+	}
+	fmt.Print(text)
+	return 0
+}
@@ -0,0 +1,185 @@
+// cmd/cli_history.go
+package main
+
+import (
+	"GWatch/internal/entity"
+	configimpl "GWatch/internal/infra/config"
+	monitoringImpl "GWatch/internal/infra/monitoring"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const historyUsage = `用法:
+  gwatch history [--since 24h | --since "2006-01-02 15:04"] [--until "2006-01-02 15:04"]
+                 [--type 告警类型] [--host 主机] [--target 对象] [--event fired,resolved,silenced,inhibited]
+                 [--limit 200] [--format text|csv|json] [--output 文件]
+
+--type/--host/--target 支持通配符（path.Match 语法），多个值用逗号分隔
+`
+
+// historyTimeLayouts --since/--until 支持的时间格式（本地时间）
+var historyTimeLayouts = []string{time.DateTime, "2006-01-02 15:04", time.DateOnly, time.RFC3339}
+
+// runHistory 查询告警历史，以文本、CSV 或 JSON 输出
+func runHistory(opts cliOptions, args []string) int {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), historyUsage)
+		fs.PrintDefaults()
+	}
+	since := fs.String("since", "24h", "开始时间：时长（如 24h 表示最近 24 小时）或本地时间 2006-01-02 15:04")
+	until := fs.String("until", "", "结束时间（本地时间 2006-01-02 15:04），默认为当前时间")
+	types := fs.String("type", "", "告警类型，如 cpu_high,mysql_*")
+	hosts := fs.String("host", "", "主机名或主机IP")
+	targets := fs.String("target", "", "告警对象：HTTP 接口名称、网卡、挂载点、实例名称等")
+	events := fs.String("event", "", "事件类型: fired/resolved/silenced/inhibited")
+	limit := fs.Int("limit", 200, "最多输出最近的多少条，0 表示不限制")
+	format := fs.String("format", "text", "输出格式: text/csv/json")
+	output := fs.String("output", "", "导出到文件，默认输出到标准输出")
+	fs.Parse(args)
+
+	if *format != "text" && *format != "csv" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "不支持的输出格式 %q，仅支持 text/csv/json\n", *format)
+		return 2
+	}
+	if *limit < 0 {
+		fmt.Fprintln(os.Stderr, "--limit 不能为负数")
+		return 2
+	}
+	now := time.Now()
+	q := entity.HistoryQuery{
+		Events:  splitList(*events),
+		Types:   splitList(*types),
+		Hosts:   splitList(*hosts),
+		Targets: splitList(*targets),
+		Limit:   *limit,
+	}
+	for _, e := range q.Events {
+		switch e {
+		case entity.AlertEventFired, entity.AlertEventResolved, entity.AlertEventSilenced, entity.AlertEventInhibited:
+		default:
+			fmt.Fprintf(os.Stderr, "未知的事件类型 %q，仅支持 fired/resolved/silenced/inhibited\n", e)
+			return 2
+		}
+	}
+	var err error
+	if q.Since, err = parseHistoryTime(*since, now); err != nil {
+		fmt.Fprintf(os.Stderr, "--since 无效: %v\n", err)
+		return 2
+	}
+	if q.Until, err = parseHistoryTime(*until, now); err != nil {
+		fmt.Fprintf(os.Stderr, "--until 无效: %v\n", err)
+		return 2
+	}
+
+	cfg, err := configimpl.LoadConfig(opts.configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "加载配置文件 %s 失败: %v\n", opts.configPath, err)
+		return 1
+	}
+	if !cfg.History.IsEnabled() {
+		fmt.Fprintln(os.Stderr, "提示: history.enabled 为 false，告警历史不再更新")
+	}
+	result, err := monitoringImpl.QueryHistory(cfg.History.File, q)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "查询告警历史失败: %v\n", err)
+		return 1
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "创建输出文件失败: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	switch *format {
+	case "json":
+		err = writeHistoryJSON(w, result)
+	case "csv":
+		err = writeHistoryCSV(w, result)
+	default:
+		writeHistoryText(w, result)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "输出告警历史失败: %v\n", err)
+		return 1
+	}
+	if *output != "" {
+		fmt.Printf("已导出 %d 条告警历史到 %s\n", len(result), *output)
+	}
+	return 0
+}
+
+// parseHistoryTime 解析时长（相对 now 向前）或本地时间，空字符串返回零值（不限制）
+func parseHistoryTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range historyTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q 既不是时长（如 24h）也不是时间（如 2006-01-02 15:04）", s)
+}
+
+func writeHistoryText(w io.Writer, events []entity.AlertEvent) {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "（无告警历史）")
+		return
+	}
+	for _, e := range events {
+		line := fmt.Sprintf("%s  %-9s %-8s %s  %s", e.Time.Format(time.DateTime), e.Event, e.Severity, e.Host, e.Message)
+		if e.Event == entity.AlertEventResolved {
+			line += fmt.Sprintf("（持续 %v）", e.Duration.Round(time.Second))
+		}
+		if e.SilencedBy != "" {
+			line += "  [" + e.SilencedBy + "]"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func writeHistoryJSON(w io.Writer, events []entity.AlertEvent) error {
+	if events == nil {
+		events = []entity.AlertEvent{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(events)
+}
+
+func writeHistoryCSV(w io.Writer, events []entity.AlertEvent) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "event", "type", "severity", "host", "ip", "target", "value", "message",
+		"silenced_by", "escalated", "flapping", "fired_at", "duration_seconds"})
+	for _, e := range events {
+		firedAt, duration := "", ""
+		if !e.FiredAt.IsZero() {
+			firedAt = e.FiredAt.Format(time.RFC3339)
+			duration = strconv.FormatFloat(e.Duration.Seconds(), 'f', 0, 64)
+		}
+		cw.Write([]string{
+			e.Time.Format(time.RFC3339), e.Event, string(e.Type), e.Severity, e.Host, e.IP, e.Target,
+			strconv.FormatFloat(e.Value, 'f', -1, 64), e.Message, e.SilencedBy,
+			strconv.FormatBool(e.Escalated), strconv.FormatBool(e.Flapping), firedAt, duration,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,169 @@
+// cmd/cli_silence.go
+package main
+
+import (
+	"GWatch/internal/entity"
+	configimpl "GWatch/internal/infra/config"
+	monitoringImpl "GWatch/internal/infra/monitoring"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const silenceUsage = `用法:
+  gwatch silence add --comment <说明> [--type 告警类型] [--host 主机] [--target 对象] [--duration 1h | --until "2006-01-02 15:04"]
+  gwatch silence list
+  gwatch silence expire <id>
+
+--type/--host/--target 支持通配符（path.Match 语法），多个值用逗号分隔，至少指定一项
+`
+
+// runSilence 管理临时静默：写入 silences.file，运行中的进程自动读取
+func runSilence(opts cliOptions, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, silenceUsage)
+		return 2
+	}
+	cfg, err := configimpl.LoadConfig(opts.configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "加载配置文件 %s 失败: %v\n", opts.configPath, err)
+		return 1
+	}
+	switch args[0] {
+	case "add":
+		return runSilenceAdd(cfg, args[1:])
+	case "list":
+		return runSilenceList(cfg)
+	case "expire":
+		if len(args) != 2 {
+			fmt.Fprint(os.Stderr, silenceUsage)
+			return 2
+		}
+		if err := monitoringImpl.ExpireSilence(cfg.Silences.File, args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "结束静默失败: %v\n", err)
+			return 1
+		}
+		fmt.Printf("静默 %s 已结束\n", args[1])
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "未知的 silence 子命令: %s\n\n%s", args[0], silenceUsage)
+		return 2
+	}
+}
+
+// runSilenceAdd 新建临时静默
+func runSilenceAdd(cfg *entity.Config, args []string) int {
+	fs := flag.NewFlagSet("silence add", flag.ExitOnError)
+	types := fs.String("type", "", "告警类型，如 cpu_high,mysql_*")
+	hosts := fs.String("host", "", "主机名或主机IP")
+	targets := fs.String("target", "", "告警对象：HTTP 接口名称、网卡、挂载点、实例名称等")
+	duration := fs.Duration("duration", time.Hour, "静默时长")
+	until := fs.String("until", "", "静默结束时间（本地时间，格式 2006-01-02 15:04），优先于 --duration")
+	comment := fs.String("comment", "", "静默原因（必填）")
+	fs.Parse(args)
+
+	matchers := entity.SilenceMatchers{
+		AlertTypes: splitList(*types),
+		Hosts:      splitList(*hosts),
+		Targets:    splitList(*targets),
+	}
+	if len(matchers.AlertTypes)+len(matchers.Hosts)+len(matchers.Targets) == 0 {
+		fmt.Fprintln(os.Stderr, "至少需要指定 --type、--host、--target 中的一项")
+		return 2
+	}
+	for _, p := range append(append(append([]string{}, matchers.AlertTypes...), matchers.Hosts...), matchers.Targets...) {
+		if _, err := path.Match(p, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "通配符模式无效: %q\n", p)
+			return 2
+		}
+	}
+	if strings.TrimSpace(*comment) == "" {
+		fmt.Fprintln(os.Stderr, "--comment 不能为空")
+		return 2
+	}
+
+	now := time.Now()
+	endsAt := now.Add(*duration)
+	if *until != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04", *until, time.Local)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "--until 格式应为 2006-01-02 15:04: %v\n", err)
+			return 2
+		}
+		endsAt = t
+	}
+	if !endsAt.After(now) {
+		fmt.Fprintln(os.Stderr, "静默结束时间必须晚于当前时间")
+		return 2
+	}
+
+	id, err := monitoringImpl.AddSilence(cfg.Silences.File, entity.Silence{
+		SilenceMatchers: matchers,
+		StartsAt:        now,
+		EndsAt:          endsAt,
+		Comment:         *comment,
+		CreatedBy:       os.Getenv("USER"),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "新建静默失败: %v\n", err)
+		return 1
+	}
+	fmt.Printf("静默 %s 已创建: %s，至 %s\n", id, matchers, endsAt.Format(time.DateTime))
+	return 0
+}
+
+// runSilenceList 列出当前生效的维护窗口与未过期的临时静默
+func runSilenceList(cfg *entity.Config) int {
+	now := time.Now()
+	fmt.Println("维护窗口:")
+	if len(cfg.Silences.MaintenanceWindows) == 0 {
+		fmt.Println("  （未配置）")
+	}
+	active := map[string]entity.Silence{}
+	for _, s := range monitoringImpl.NewSilencer().Active(cfg, now) {
+		if s.IsMaintenance() {
+			active[s.ID] = s
+		}
+	}
+	for _, w := range cfg.Silences.MaintenanceWindows {
+		state := "未生效"
+		if s, ok := active[w.Name]; ok {
+			state = "生效中，至 " + s.EndsAt.Format(time.DateTime)
+		}
+		fmt.Printf("  %s  [%s 持续 %v %s]  %s  %s  %s\n", w.Name, w.Schedule, w.Duration, w.Timezone, w.SilenceMatchers, state, w.Comment)
+	}
+
+	silences, err := monitoringImpl.LoadSilences(cfg.Silences.File)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "读取静默文件失败: %v\n", err)
+		return 1
+	}
+	fmt.Println("临时静默:")
+	shown := 0
+	for _, s := range silences {
+		if !now.Before(s.EndsAt) {
+			continue
+		}
+		shown++
+		fmt.Printf("  %s  %s  %s ~ %s  %s  %s\n", s.ID, s.SilenceMatchers,
+			s.StartsAt.Format(time.DateTime), s.EndsAt.Format(time.DateTime), s.CreatedBy, s.Comment)
+	}
+	if shown == 0 {
+		fmt.Println("  （无）")
+	}
+	return 0
+}
+
+// splitList 解析逗号分隔的参数值，忽略空项
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -3,26 +3,127 @@ package main
 
 import (
 	"GWatch/internal/infra/logger"
+	"flag"
+	"fmt"
 	"log"
+	"os"
 )
 
+// Version 版本号，编译时通过 -ldflags "-X main.Version=..." 注入
+var Version = "dev"
+
+const usage = `GWatch 服务器监控工具
+
+用法:
+  gwatch [全局参数] <命令> [命令参数]
+
+命令:
+  run            持续运行监控（默认）
+  once           采集并判断一次，输出指标快照后退出（--format text|json）
+  test-notify    向所有已启用的通知渠道发送一条测试告警
+  push-now       立即执行一次全局定时推送
+  check-config   校验配置文件，有错误时退出码非0
+  print-config   输出合并 include 目录后实际生效的配置（敏感信息已脱敏）
+  print-template 输出内置消息模板（alert/recovery/ticker_report/scheduled_report），作为自定义模板的起点
+  silence        管理临时静默（add/list/expire），命中的告警只记录不发送
+  history        查询告警历史（按时间、类型、主机过滤），输出为 text/csv/json
+  version        显示版本号
+
+全局参数:
+`
+
+// cliOptions 全局命令行参数
+type cliOptions struct {
+	configPath string
+	logLevel   string
+}
+
 func main() {
-	log.Println("GWatch 服务器监控工具启动")
+	opts := cliOptions{}
+	fs := flag.NewFlagSet("gwatch", flag.ExitOnError)
+	fs.StringVar(&opts.configPath, "config", defaultConfigPath(), "配置文件路径（默认读取环境变量 GWATCH_CONFIG）")
+	fs.StringVar(&opts.logLevel, "log-level", "", "覆盖配置中的日志级别: debug/info/warn/error")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(os.Args[1:])
+
+	if opts.logLevel != "" && !logger.IsValidLogLevel(opts.logLevel) {
+		fmt.Fprintf(os.Stderr, "无效的日志级别 %q，仅支持 debug/info/warn/error\n", opts.logLevel)
+		os.Exit(2)
+	}
+
+	command, args := "run", []string{}
+	if fs.NArg() > 0 {
+		command, args = fs.Arg(0), fs.Args()[1:]
+	}
+
+	switch command {
+	case "run":
+		os.Exit(runServe(opts))
+	case "once":
+		os.Exit(runOnce(opts, args))
+	case "test-notify":
+		os.Exit(runTestNotify(opts))
+	case "push-now":
+		os.Exit(runPushNow(opts))
+	case "check-config":
+		os.Exit(runCheckConfig(opts, args))
+	case "print-config":
+		os.Exit(runPrintConfig(opts))
+	case "print-template":
+		os.Exit(runPrintTemplate(args))
+	case "silence":
+		os.Exit(runSilence(opts, args))
+	case "history":
+		os.Exit(runHistory(opts, args))
+	case "version":
+		fmt.Printf("GWatch %s\n", Version)
+	case "help":
+		fs.Usage()
+	default:
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", command)
+		fs.Usage()
+		os.Exit(2)
+	}
+}
+
+// defaultConfigPath 获取默认配置文件路径：环境变量 GWATCH_CONFIG，默认 config/config.yml（通过mode字段区分client/server）
+func defaultConfigPath() string {
+	if path := os.Getenv("GWATCH_CONFIG"); path != "" {
+		return path
+	}
+	return "config/config.yml"
+}
+
+// runServe 持续运行监控（原有默认行为）
+func runServe(opts cliOptions) int {
+	log.Printf("GWatch 服务器监控工具启动 (版本 %s)", Version)
 	log.Println("正在初始化...")
 
-	// 1. 使用 Wire 进行依赖注入
-	app, err := InitializeApp()
+	// 1. 使用 Wire 进行依赖注入（加载配置时会执行完整校验，有错误则拒绝启动）
+	app, err := InitializeApp(ConfigPath(opts.configPath))
 	if err != nil {
 		log.Printf("初始化应用程序失败: %v\n", err)
-		return
+		return 1
 	}
 
 	// 2. 初始化日志系统
-	logger.InitLogWrapper(app.LoggerService.GetLogger())
+	logger.InitLogWrapper(app.LoggerService.GetLogger(), opts.effectiveLogLevel(app))
 
 	// 3. 启动应用程序
 	if err := app.Start(); err != nil {
 		log.Printf("应用程序运行失败: %v\n", err)
-		return
+		return 1
+	}
+	return 0
+}
+
+// effectiveLogLevel 命令行 --log-level 优先，否则使用配置文件中的 log.level
+func (opts cliOptions) effectiveLogLevel(app *App) string {
+	if opts.logLevel != "" {
+		return opts.logLevel
 	}
+	return app.Config().Log.Level
 }
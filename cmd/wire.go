@@ -4,6 +4,7 @@
 package main
 
 import (
+	"fmt"
 	"GWatch/internal/app/usecase"
 	"GWatch/internal/domain/collector"
 	"GWatch/internal/domain/config"
@@ -32,6 +33,9 @@ import (
 	"github.com/google/wire"
 )
 
+// ConfigPath 配置文件路径（由命令行参数 --config 或环境变量决定）
+type ConfigPath string
+
 // BasePolicy 基础告警策略类型别名
 type BasePolicy *monitoringImpl.StatefulPolicy
 
@@ -61,16 +65,27 @@ var ProviderSet = wire.NewSet(
 	NewTokenProvider,
 
 	// 评估器和格式化器提供者
+	NewDiskForecastEvaluator,
+	NewDiskForecaster,
+	NewAnomalyEvaluator,
 	NewEvaluator,
 	NewMarkdownFormatter,
 	NewTickerMarkdownFormatter,
 
-	// 通知器提供者
-	NewDingTalkNotifier,
+	// 通知器提供者（发件箱由各注入器分别提供）
+	NewNotifier,
+
+	// 静默判断提供者
+	NewSilencer,
+
+	// 告警历史提供者
+	NewAlertHistory,
 
 	// 告警策略提供者
 	NewBasePolicy,
 	NewHTTPPolicy,
+	NewPolicyStateStore,
+	NewFlapHistory,
 
 	// 系统指标服务提供者
 	NewSystemMetricsService,
@@ -85,13 +100,8 @@ var ProviderSet = wire.NewSet(
 )
 
 // NewConfigProvider 创建配置提供者
-func NewConfigProvider() (config.Provider, error) {
-	// 从环境变量获取配置文件路径，默认使用 config.yml（通过mode字段区分client/server）
-	configPath := os.Getenv("GWATCH_CONFIG")
-	if configPath == "" {
-		configPath = "config/config.yml"
-	}
-	return configimpl.NewYAMLProvider(configPath)
+func NewConfigProvider(path ConfigPath) (config.Provider, error) {
+	return configimpl.NewYAMLProvider(string(path))
 }
 
 // NewHostCollector 创建主机信息收集器
@@ -125,9 +135,25 @@ func NewTokenProvider() ticker.TokenProvider {
 	return tickerAuth.NewTokenProvider()
 }
 
-// NewEvaluator 创建评估器
-func NewEvaluator() monitoring.Evaluator {
-	return monitoringImpl.NewSimpleEvaluator()
+// NewDiskForecastEvaluator 创建内置评估器：阈值判断 + 磁盘写满预测
+func NewDiskForecastEvaluator() *monitoringImpl.DiskForecastEvaluator {
+	return monitoringImpl.NewDiskForecastEvaluator(monitoringImpl.NewSimpleEvaluator())
+}
+
+// NewDiskForecaster 磁盘写满预测结果查询（用于定时报告），与评估器共用同一实例
+func NewDiskForecaster(builtin *monitoringImpl.DiskForecastEvaluator) monitoring.DiskForecaster {
+	return builtin
+}
+
+// NewAnomalyEvaluator 创建异常检测评估器：内置阈值判断与写满预测 + 配置中的自定义规则 + 动态基线异常检测
+// 异常检测在自定义规则之外，rules.disable_builtin 不影响异常检测
+func NewAnomalyEvaluator(provider config.Provider, builtin *monitoringImpl.DiskForecastEvaluator) *monitoringImpl.AnomalyEvaluator {
+	return monitoringImpl.NewAnomalyEvaluator(provider, monitoringImpl.NewRuleEvaluator(builtin))
+}
+
+// NewEvaluator 创建评估器，与基线持久化共用同一异常检测实例
+func NewEvaluator(anomaly *monitoringImpl.AnomalyEvaluator) monitoring.Evaluator {
+	return anomaly
 }
 
 // NewMarkdownFormatter 创建 Markdown 格式化器
@@ -140,9 +166,29 @@ func NewTickerMarkdownFormatter() monitoring.TickerFormatter {
 	return monitoringImpl.NewTickerMarkdownFormatter().(monitoring.TickerFormatter)
 }
 
-// NewDingTalkNotifier 创建钉钉通知器
-func NewDingTalkNotifier(provider config.Provider) monitoring.Notifier {
-	return monitoringImpl.NewDingTalkNotifier(provider)
+// NewNotificationOutbox 创建通知发件箱（持久化待发送通知并重试）
+func NewNotificationOutbox(provider config.Provider) *monitoringImpl.Outbox {
+	return monitoringImpl.NewOutbox(provider)
+}
+
+// NewDirectNotificationOutbox 创建不落盘的通知发件箱（单次执行的命令直接发送，失败不重试）
+func NewDirectNotificationOutbox(provider config.Provider) *monitoringImpl.Outbox {
+	return monitoringImpl.NewDirectOutbox(provider)
+}
+
+// NewNotifier 创建多渠道通知器（dingtalk 及 notifiers 中已启用的渠道）
+func NewNotifier(provider config.Provider, outbox *monitoringImpl.Outbox) monitoring.Notifier {
+	return monitoringImpl.NewNotifier(provider, outbox)
+}
+
+// NewSilencer 创建静默判断器（维护窗口与临时静默）
+func NewSilencer() monitoring.Silencer {
+	return monitoringImpl.NewSilencer()
+}
+
+// NewAlertHistory 创建告警历史（发送、恢复、静默与抑制的告警写入 history.file）
+func NewAlertHistory() monitoring.AlertHistory {
+	return monitoringImpl.NewBoltHistory()
 }
 
 // NewBasePolicy 创建基础告警策略
@@ -155,10 +201,41 @@ func NewHTTPPolicy() HTTPPolicy {
 	return monitoringImpl.NewStatefulPolicy().(*monitoringImpl.StatefulPolicy)
 }
 
+// NewPolicyStateStore 创建告警策略状态持久化（重启后恢复连续计数、防抖时间与未恢复的告警）
+func NewPolicyStateStore(provider config.Provider, policyBase BasePolicy, policyHTTP HTTPPolicy) *monitoringImpl.PolicyStateStore {
+	return monitoringImpl.NewPolicyStateStore(provider, (*monitoringImpl.StatefulPolicy)(policyBase), (*monitoringImpl.StatefulPolicy)(policyHTTP))
+}
+
+// NewFlapHistory 创建告警抖动记录查询（合并基础与 HTTP 告警策略）
+func NewFlapHistory(policyBase BasePolicy, policyHTTP HTTPPolicy) monitoring.FlapHistory {
+	return monitoringImpl.FlapHistories{(*monitoringImpl.StatefulPolicy)(policyBase), (*monitoringImpl.StatefulPolicy)(policyHTTP)}
+}
+
 // InitializeApp 初始化应用程序的所有依赖
-func InitializeApp() (*App, error) {
+func InitializeApp(path ConfigPath) (*App, error) {
+	wire.Build(
+		ProviderSet,
+		NewNotificationOutbox,
+		NewTickerUseCase,
+		NewCoordinator,
+		NewTickerScheduler,
+		NewScheduledPushAlertStorage,
+		NewScheduledPushUseCase,
+		NewScheduledPushScheduler,
+		NewLoggerFactory,
+		NewLogger,
+		NewLoggerService,
+		NewApp,
+	)
+	return &App{}, nil
+}
+
+// InitializeOneShotApp 初始化单次执行的命令（once/test-notify/push-now）使用的应用：
+// 通知直接发送，不经过落盘的发件箱
+func InitializeOneShotApp(path ConfigPath) (*App, error) {
 	wire.Build(
 		ProviderSet,
+		NewDirectNotificationOutbox,
 		NewTickerUseCase,
 		NewCoordinator,
 		NewTickerScheduler,
@@ -183,6 +260,8 @@ func NewBaseMonitoringUseCase(
 	policy BasePolicy,
 	formatter monitoring.Formatter,
 	notifier monitoring.Notifier,
+	silencer monitoring.Silencer,
+	history monitoring.AlertHistory,
 ) BaseMonitoringUseCase {
 	return usecase.NewMonitoringUseCase(
 		hostInfo,
@@ -193,6 +272,8 @@ func NewBaseMonitoringUseCase(
 		(*monitoringImpl.StatefulPolicy)(policy),
 		formatter,
 		notifier,
+		silencer,
+		history,
 	)
 }
 
@@ -206,6 +287,8 @@ func NewHTTPMonitoringUseCase(
 	policy HTTPPolicy,
 	formatter monitoring.Formatter,
 	notifier monitoring.Notifier,
+	silencer monitoring.Silencer,
+	history monitoring.AlertHistory,
 ) HTTPMonitoringUseCase {
 	return usecase.NewMonitoringUseCase(
 		hostInfo,
@@ -216,6 +299,8 @@ func NewHTTPMonitoringUseCase(
 		(*monitoringImpl.StatefulPolicy)(policy),
 		formatter,
 		notifier,
+		silencer,
+		history,
 	)
 }
 
@@ -283,6 +368,9 @@ func NewScheduledPushUseCase(
 	alertStorage scheduled_push.ScheduledPushAlertStorage,
 	clientDataRepository scheduled_push.ClientDataRepository,
 	scheduledPushFormatter scheduled_push.ScheduledPushFormatter,
+	silencer monitoring.Silencer,
+	flapHistory monitoring.FlapHistory,
+	diskForecaster monitoring.DiskForecaster,
 ) scheduled_push.ScheduledPushUseCase {
 	return usecase.NewScheduledPushUseCase(
 		hostInfo,
@@ -297,6 +385,9 @@ func NewScheduledPushUseCase(
 		alertStorage,
 		clientDataRepository,
 		scheduledPushFormatter,
+		silencer,
+		flapHistory,
+		diskForecaster,
 	)
 }
 
@@ -306,8 +397,8 @@ func NewClientDataRepository() scheduled_push.ClientDataRepository {
 }
 
 // NewScheduledPushFormatter 创建定时推送格式化器
-func NewScheduledPushFormatter() scheduled_push.ScheduledPushFormatter {
-	return monitoringImpl.NewScheduledPushFormatter()
+func NewScheduledPushFormatter(provider config.Provider) scheduled_push.ScheduledPushFormatter {
+	return monitoringImpl.NewScheduledPushFormatter(provider)
 }
 
 // NewScheduledPushAlertStorage 创建全局定时推送告警存储
@@ -345,7 +436,13 @@ func NewLoggerService(logger logger.Logger) *usecase.LoggerService {
 
 // App 应用程序结构体，包含所有需要的组件
 type App struct {
-	Config                *entity.Config
+	Provider              config.Provider
+	Notifier              monitoring.Notifier
+	Outbox                monitoring.Outbox
+	PolicyState           monitoring.PolicyStateStore
+	Baselines             monitoring.BaselineStore
+	Monitor               *usecase.MonitoringUseCase
+	ScheduledPushUseCase  scheduled_push.ScheduledPushUseCase
 	Coordinator           *usecase.Coordinator
 	TickerScheduler       ticker.TickerScheduler
 	ScheduledPushScheduler scheduled_push.ScheduledPushScheduler
@@ -357,28 +454,57 @@ func (app *App) Start() error {
 	log.Println("开始监控...")
 	
 	// 打印监控状态
-	app.printMonitoringStatus()
+	app.printMonitoringStatus(app.Config())
+	
+	// 恢复上次运行时的告警策略状态，避免重启后重复发送未恢复的告警
+	app.PolicyState.Restore()
+	
+	// 恢复异常检测基线，重启后无需重新学习
+	app.Baselines.Restore()
 	
 	// 设置信号监听
 	stopCh := make(chan struct{})
 	go app.handleSignals(stopCh)
 	
+	// 后台重试发送失败的通知
+	go app.Outbox.Run(stopCh)
+	
+	// 定期保存告警策略状态
+	go app.PolicyState.Run(stopCh)
+	
+	// 定期保存异常检测基线
+	go app.Baselines.Run(stopCh)
+	
+	// 配置热加载：新配置校验通过后同步给各调度器，文件变化自动重新加载
+	app.Provider.Subscribe(app.applyConfig)
+	go app.Provider.Watch(stopCh, app.notifyReloadError)
+	
 	// 启动调度器
 	if err := app.startSchedulers(stopCh); err != nil {
 		return err
 	}
 	
 	// 启动监控协调器（阻塞运行）
-	app.Coordinator.RunWithIntervals(app.Config, stopCh)
+	app.Coordinator.RunWithIntervals(app.Config(), stopCh)
 	
 	log.Println("GWatch 正在退出...")
+	// 监控循环已全部结束，保存最终的告警策略状态
+	if err := app.PolicyState.Save(); err != nil {
+		log.Printf("[ERROR] 保存告警策略状态失败: %v", err)
+	}
+	if err := app.Baselines.Save(); err != nil {
+		log.Printf("[ERROR] 保存异常检测基线失败: %v", err)
+	}
 	return nil
 }
 
+// Config 返回当前生效的配置（热加载后为最新配置）
+func (app *App) Config() *entity.Config {
+	return app.Provider.GetConfig()
+}
+
 // printMonitoringStatus 打印监控状态
-func (app *App) printMonitoringStatus() {
-	cfg := app.Config
-	
+func (app *App) printMonitoringStatus(cfg *entity.Config) {	
 	if cfg.HostMonitoring != nil && cfg.HostMonitoring.Enabled {
 		log.Println("主机监控已启用，监控间隔:", cfg.HostMonitoring.Interval)
 	} else if cfg.HostMonitoring != nil && !cfg.HostMonitoring.Enabled {
@@ -388,14 +514,14 @@ func (app *App) printMonitoringStatus() {
 	// 应用层监控状态
 	if cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled {
 		log.Println("应用层监控已启用")
-		if cfg.AppMonitoring.Redis != nil && cfg.AppMonitoring.Redis.Enabled {
-			log.Println("  - Redis监控已启用")
-		} else if cfg.AppMonitoring.Redis != nil && !cfg.AppMonitoring.Redis.Enabled {
+		if targets := cfg.AppMonitoring.RedisTargets(); len(targets) > 0 {
+			log.Printf("  - Redis监控已启用，实例数: %d", len(targets))
+		} else if cfg.AppMonitoring.Redis != nil || len(cfg.AppMonitoring.RedisInstances) > 0 {
 			log.Println("  - Redis监控已禁用")
 		}
-		if cfg.AppMonitoring.MySQL != nil && cfg.AppMonitoring.MySQL.Enabled {
-			log.Println("  - MySQL监控已启用")
-		} else if cfg.AppMonitoring.MySQL != nil && !cfg.AppMonitoring.MySQL.Enabled {
+		if targets := cfg.AppMonitoring.MySQLTargets(); len(targets) > 0 {
+			log.Printf("  - MySQL监控已启用，实例数: %d", len(targets))
+		} else if cfg.AppMonitoring.MySQL != nil || len(cfg.AppMonitoring.MySQLInstances) > 0 {
 			log.Println("  - MySQL监控已禁用")
 		}
 		if cfg.AppMonitoring.HTTP != nil && cfg.AppMonitoring.HTTP.Enabled {
@@ -411,37 +537,89 @@ func (app *App) printMonitoringStatus() {
 	} else if cfg.AppMonitoring != nil && !cfg.AppMonitoring.Enabled {
 		log.Println("应用层监控已禁用")
 	}
+	
+	if cfg.Outbox.IsEnabled() {
+		stats := app.Outbox.Stats()
+		log.Printf("通知发件箱已启用，待发送: %d 条，发送失败: %d 条", stats.Pending, stats.Failed)
+	} else {
+		log.Println("通知发件箱已禁用")
+	}
+	
+	if cfg.PolicyState.IsEnabled() {
+		log.Printf("告警策略状态持久化已启用，状态文件: %s，保存间隔: %v", cfg.PolicyState.File, cfg.PolicyState.SaveInterval)
+	} else {
+		log.Println("告警策略状态持久化已禁用")
+	}
+	
+	if cfg.History.IsEnabled() {
+		log.Printf("告警历史已启用，数据库文件: %s，保留: %v", cfg.History.File, cfg.History.Retention)
+	} else {
+		log.Println("告警历史已禁用")
+	}
+	
+	if a := cfg.Anomaly; a != nil {
+		log.Printf("动态基线异常检测已启用，指标: %v，阈值: %.1fσ，持续: %v，基线文件: %s", a.Metrics, a.Sigma, a.For, a.File)
+	} else {
+		log.Println("动态基线异常检测未启用")
+	}
 }
 
-// handleSignals 处理系统信号
+// handleSignals 处理系统信号：SIGHUP 重新加载配置，SIGINT/SIGTERM 优雅退出
 func (app *App) handleSignals(stopCh chan struct{}) {
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	sig := <-c
-	log.Printf("接收到信号 %v，正在优雅退出...\n", sig)
-	close(stopCh)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range c {
+		if sig == syscall.SIGHUP {
+			log.Println("接收到 SIGHUP 信号，重新加载配置...")
+			if err := app.Provider.Reload(); err != nil {
+				log.Printf("[ERROR] %v", err)
+				app.notifyReloadError(err)
+			}
+			continue
+		}
+		log.Printf("接收到信号 %v，正在优雅退出...\n", sig)
+		close(stopCh)
+		return
+	}
+}
+
+// applyConfig 将热加载后的配置同步到协调器和各调度器，告警策略状态保持不变
+func (app *App) applyConfig(cfg *entity.Config) {
+	app.Coordinator.UpdateConfig(cfg)
+	app.TickerScheduler.UpdateConfig(cfg)
+	app.ScheduledPushScheduler.UpdateConfig(cfg)
+	app.printMonitoringStatus(cfg)
+}
+
+// notifyReloadError 配置热加载失败时发送钉钉通知
+func (app *App) notifyReloadError(err error) {
+	if app.Notifier == nil {
+		return
+	}
+	title := "GWatch 配置热加载失败"
+	markdown := fmt.Sprintf("### %s\n\n- 错误: %v\n- 处理: 已拒绝新配置，继续使用旧配置运行\n", title, err)
+	if sendErr := app.Notifier.Send(title, markdown); sendErr != nil {
+		log.Printf("发送配置热加载失败通知失败: %v", sendErr)
+	}
 }
 
 // startSchedulers 启动所有调度器
+// 调度器总是启动，是否执行由每次检查时的最新配置决定，以便热加载开启/关闭功能
 func (app *App) startSchedulers(stopCh <-chan struct{}) error {
-	cfg := app.Config
+	cfg := app.Config()
 	
 	// 启动Ticker调度器
-	if cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled && cfg.AppMonitoring.Tickers != nil && cfg.AppMonitoring.Tickers.Enabled && len(cfg.AppMonitoring.Tickers.TickerInterfaces) > 0 {
-		log.Println("启动定时器调度器...")
-		if err := app.TickerScheduler.Start(cfg, stopCh); err != nil {
-			log.Printf("启动定时器调度器失败: %v", err)
-			return err
-		}
+	log.Println("启动定时器调度器...")
+	if err := app.TickerScheduler.Start(cfg, stopCh); err != nil {
+		log.Printf("启动定时器调度器失败: %v", err)
+		return err
 	}
 	
 	// 启动全局定时推送调度器
-	if cfg.ScheduledPush != nil && cfg.ScheduledPush.Enabled {
-		log.Println("启动全局定时推送调度器...")
-		if err := app.ScheduledPushScheduler.Start(cfg, stopCh); err != nil {
-			log.Printf("启动全局定时推送调度器失败: %v", err)
-			return err
-		}
+	log.Println("启动全局定时推送调度器...")
+	if err := app.ScheduledPushScheduler.Start(cfg, stopCh); err != nil {
+		log.Printf("启动全局定时推送调度器失败: %v", err)
+		return err
 	}
 	
 	return nil
@@ -449,14 +627,26 @@ func (app *App) startSchedulers(stopCh <-chan struct{}) error {
 
 // NewApp 创建应用程序实例
 func NewApp(
-	config *entity.Config,
+	provider config.Provider,
+	notifier monitoring.Notifier,
+	outbox *monitoringImpl.Outbox,
+	policyState *monitoringImpl.PolicyStateStore,
+	baselines *monitoringImpl.AnomalyEvaluator,
+	monitor BaseMonitoringUseCase,
+	scheduledPushUseCase scheduled_push.ScheduledPushUseCase,
 	coordinator *usecase.Coordinator,
 	tickerScheduler ticker.TickerScheduler,
 	scheduledPushScheduler scheduled_push.ScheduledPushScheduler,
 	loggerService *usecase.LoggerService,
 ) *App {
 	return &App{
-		Config:                config,
+		Provider:              provider,
+		Notifier:              notifier,
+		Outbox:                outbox,
+		PolicyState:           policyState,
+		Baselines:             baselines,
+		Monitor:               (*usecase.MonitoringUseCase)(monitor),
+		ScheduledPushUseCase:  scheduledPushUseCase,
 		Coordinator:           coordinator,
 		TickerScheduler:       tickerScheduler,
 		ScheduledPushScheduler: scheduledPushScheduler,
@@ -24,6 +24,7 @@ import (
 	scheduled_push2 "GWatch/internal/infra/scheduled_push"
 	ticker2 "GWatch/internal/infra/ticker"
 	"GWatch/internal/infra/ticker/auth"
+	"fmt"
 	"github.com/google/wire"
 	"log"
 	"os"
@@ -34,44 +35,101 @@ import (
 // Injectors from wire.go:
 
 // InitializeApp 初始化应用程序的所有依赖
-func InitializeApp() (*App, error) {
-	provider, err := NewConfigProvider()
+func InitializeApp(path ConfigPath) (*App, error) {
+	provider, err := NewConfigProvider(path)
 	if err != nil {
 		return nil, err
 	}
-	config := NewConfig(provider)
+	outbox := NewNotificationOutbox(provider)
+	notifier := NewNotifier(provider, outbox)
+	basePolicy := NewBasePolicy()
+	httpPolicy := NewHTTPPolicy()
+	policyStateStore := NewPolicyStateStore(provider, basePolicy, httpPolicy)
+	diskForecastEvaluator := NewDiskForecastEvaluator()
+	anomalyEvaluator := NewAnomalyEvaluator(provider, diskForecastEvaluator)
 	hostCollector := NewHostCollector()
 	redisClient := NewRedisCollector(provider)
 	mySQLCollector := NewMySQLCollector(provider)
 	httpCollector := NewHTTPCollector(provider)
-	evaluator := NewEvaluator()
-	basePolicy := NewBasePolicy()
+	evaluator := NewEvaluator(anomalyEvaluator)
 	formatter := NewMarkdownFormatter()
-	notifier := NewDingTalkNotifier(provider)
-	baseMonitoringUseCase := NewBaseMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, basePolicy, formatter, notifier)
-	httpPolicy := NewHTTPPolicy()
-	httpMonitoringUseCase := NewHTTPMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, httpPolicy, formatter, notifier)
-	coordinator := NewCoordinator(baseMonitoringUseCase, httpMonitoringUseCase, basePolicy, httpPolicy)
+	silencer := NewSilencer()
+	alertHistory := NewAlertHistory()
+	baseMonitoringUseCase := NewBaseMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, basePolicy, formatter, notifier, silencer, alertHistory)
 	tokenProvider := NewTokenProvider()
 	tickerCollector := NewTickerCollector(tokenProvider)
 	systemMetricsService := NewSystemMetricsService(hostCollector, redisClient, httpCollector)
+	config := NewConfig(provider)
+	scheduledPushAlertStorage := NewScheduledPushAlertStorage(config)
+	clientDataRepository := NewClientDataRepository()
+	scheduledPushFormatter := NewScheduledPushFormatter(provider)
+	flapHistory := NewFlapHistory(basePolicy, httpPolicy)
+	diskForecaster := NewDiskForecaster(diskForecastEvaluator)
+	scheduledPushUseCase := NewScheduledPushUseCase(hostCollector, redisClient, httpCollector, tickerCollector, tokenProvider, systemMetricsService, evaluator, formatter, notifier, scheduledPushAlertStorage, clientDataRepository, scheduledPushFormatter, silencer, flapHistory, diskForecaster)
+	httpMonitoringUseCase := NewHTTPMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, httpPolicy, formatter, notifier, silencer, alertHistory)
+	coordinator := NewCoordinator(baseMonitoringUseCase, httpMonitoringUseCase, basePolicy, httpPolicy)
 	tickerFormatter := NewTickerMarkdownFormatter()
 	tickerUseCase := NewTickerUseCase(tickerCollector, tokenProvider, systemMetricsService, evaluator, formatter, tickerFormatter, notifier)
 	tickerScheduler := NewTickerScheduler(tickerUseCase)
+	scheduledPushScheduler := NewScheduledPushScheduler(scheduledPushUseCase)
+	loggerFactory := NewLoggerFactory(config)
+	logger := NewLogger(loggerFactory)
+	loggerService := NewLoggerService(logger)
+	app := NewApp(provider, notifier, outbox, policyStateStore, anomalyEvaluator, baseMonitoringUseCase, scheduledPushUseCase, coordinator, tickerScheduler, scheduledPushScheduler, loggerService)
+	return app, nil
+}
+
+// InitializeOneShotApp 初始化单次执行的命令（once/test-notify/push-now）使用的应用：
+// 通知直接发送，不经过落盘的发件箱
+func InitializeOneShotApp(path ConfigPath) (*App, error) {
+	provider, err := NewConfigProvider(path)
+	if err != nil {
+		return nil, err
+	}
+	outbox := NewDirectNotificationOutbox(provider)
+	notifier := NewNotifier(provider, outbox)
+	basePolicy := NewBasePolicy()
+	httpPolicy := NewHTTPPolicy()
+	policyStateStore := NewPolicyStateStore(provider, basePolicy, httpPolicy)
+	diskForecastEvaluator := NewDiskForecastEvaluator()
+	anomalyEvaluator := NewAnomalyEvaluator(provider, diskForecastEvaluator)
+	hostCollector := NewHostCollector()
+	redisClient := NewRedisCollector(provider)
+	mySQLCollector := NewMySQLCollector(provider)
+	httpCollector := NewHTTPCollector(provider)
+	evaluator := NewEvaluator(anomalyEvaluator)
+	formatter := NewMarkdownFormatter()
+	silencer := NewSilencer()
+	alertHistory := NewAlertHistory()
+	baseMonitoringUseCase := NewBaseMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, basePolicy, formatter, notifier, silencer, alertHistory)
+	tokenProvider := NewTokenProvider()
+	tickerCollector := NewTickerCollector(tokenProvider)
+	systemMetricsService := NewSystemMetricsService(hostCollector, redisClient, httpCollector)
+	config := NewConfig(provider)
 	scheduledPushAlertStorage := NewScheduledPushAlertStorage(config)
 	clientDataRepository := NewClientDataRepository()
-	scheduledPushFormatter := NewScheduledPushFormatter()
-	scheduledPushUseCase := NewScheduledPushUseCase(hostCollector, redisClient, httpCollector, tickerCollector, tokenProvider, systemMetricsService, evaluator, formatter, notifier, scheduledPushAlertStorage, clientDataRepository, scheduledPushFormatter)
+	scheduledPushFormatter := NewScheduledPushFormatter(provider)
+	flapHistory := NewFlapHistory(basePolicy, httpPolicy)
+	diskForecaster := NewDiskForecaster(diskForecastEvaluator)
+	scheduledPushUseCase := NewScheduledPushUseCase(hostCollector, redisClient, httpCollector, tickerCollector, tokenProvider, systemMetricsService, evaluator, formatter, notifier, scheduledPushAlertStorage, clientDataRepository, scheduledPushFormatter, silencer, flapHistory, diskForecaster)
+	httpMonitoringUseCase := NewHTTPMonitoringUseCase(hostCollector, redisClient, mySQLCollector, httpCollector, evaluator, httpPolicy, formatter, notifier, silencer, alertHistory)
+	coordinator := NewCoordinator(baseMonitoringUseCase, httpMonitoringUseCase, basePolicy, httpPolicy)
+	tickerFormatter := NewTickerMarkdownFormatter()
+	tickerUseCase := NewTickerUseCase(tickerCollector, tokenProvider, systemMetricsService, evaluator, formatter, tickerFormatter, notifier)
+	tickerScheduler := NewTickerScheduler(tickerUseCase)
 	scheduledPushScheduler := NewScheduledPushScheduler(scheduledPushUseCase)
 	loggerFactory := NewLoggerFactory(config)
 	logger := NewLogger(loggerFactory)
 	loggerService := NewLoggerService(logger)
-	app := NewApp(config, coordinator, tickerScheduler, scheduledPushScheduler, loggerService)
+	app := NewApp(provider, notifier, outbox, policyStateStore, anomalyEvaluator, baseMonitoringUseCase, scheduledPushUseCase, coordinator, tickerScheduler, scheduledPushScheduler, loggerService)
 	return app, nil
 }
 
 // wire.go:
 
+// ConfigPath 配置文件路径（由命令行参数 --config 或环境变量决定）
+type ConfigPath string
+
 // BasePolicy 基础告警策略类型别名
 type BasePolicy *monitoring.StatefulPolicy
 
@@ -98,14 +156,23 @@ var ProviderSet = wire.NewSet(
 
 	NewTokenProvider,
 
+	NewDiskForecastEvaluator,
+	NewDiskForecaster,
+	NewAnomalyEvaluator,
 	NewEvaluator,
 	NewMarkdownFormatter,
 	NewTickerMarkdownFormatter,
 
-	NewDingTalkNotifier,
+	NewNotifier,
+
+	NewSilencer,
+
+	NewAlertHistory,
 
 	NewBasePolicy,
 	NewHTTPPolicy,
+	NewPolicyStateStore,
+	NewFlapHistory,
 
 	NewSystemMetricsService,
 
@@ -117,13 +184,8 @@ var ProviderSet = wire.NewSet(
 )
 
 // NewConfigProvider 创建配置提供者
-func NewConfigProvider() (config.Provider, error) {
-
-	configPath := os.Getenv("GWATCH_CONFIG")
-	if configPath == "" {
-		configPath = "config/config.yml"
-	}
-	return configimpl.NewYAMLProvider(configPath)
+func NewConfigProvider(path ConfigPath) (config.Provider, error) {
+	return configimpl.NewYAMLProvider(string(path))
 }
 
 // NewHostCollector 创建主机信息收集器
@@ -156,9 +218,25 @@ func NewTokenProvider() ticker.TokenProvider {
 	return auth.NewTokenProvider()
 }
 
-// NewEvaluator 创建评估器
-func NewEvaluator() monitoring2.Evaluator {
-	return monitoring.NewSimpleEvaluator()
+// NewDiskForecastEvaluator 创建内置评估器：阈值判断 + 磁盘写满预测
+func NewDiskForecastEvaluator() *monitoring.DiskForecastEvaluator {
+	return monitoring.NewDiskForecastEvaluator(monitoring.NewSimpleEvaluator())
+}
+
+// NewDiskForecaster 磁盘写满预测结果查询（用于定时报告），与评估器共用同一实例
+func NewDiskForecaster(builtin *monitoring.DiskForecastEvaluator) monitoring2.DiskForecaster {
+	return builtin
+}
+
+// NewAnomalyEvaluator 创建异常检测评估器：内置阈值判断与写满预测 + 配置中的自定义规则 + 动态基线异常检测
+// 异常检测在自定义规则之外，rules.disable_builtin 不影响异常检测
+func NewAnomalyEvaluator(provider config.Provider, builtin *monitoring.DiskForecastEvaluator) *monitoring.AnomalyEvaluator {
+	return monitoring.NewAnomalyEvaluator(provider, monitoring.NewRuleEvaluator(builtin))
+}
+
+// NewEvaluator 创建评估器，与基线持久化共用同一异常检测实例
+func NewEvaluator(anomaly *monitoring.AnomalyEvaluator) monitoring2.Evaluator {
+	return anomaly
 }
 
 // NewMarkdownFormatter 创建 Markdown 格式化器
@@ -171,9 +249,29 @@ func NewTickerMarkdownFormatter() monitoring2.TickerFormatter {
 	return monitoring.NewTickerMarkdownFormatter().(monitoring2.TickerFormatter)
 }
 
-// NewDingTalkNotifier 创建钉钉通知器
-func NewDingTalkNotifier(provider config.Provider) monitoring2.Notifier {
-	return monitoring.NewDingTalkNotifier(provider)
+// NewNotificationOutbox 创建通知发件箱（持久化待发送通知并重试）
+func NewNotificationOutbox(provider config.Provider) *monitoring.Outbox {
+	return monitoring.NewOutbox(provider)
+}
+
+// NewDirectNotificationOutbox 创建不落盘的通知发件箱（单次执行的命令直接发送，失败不重试）
+func NewDirectNotificationOutbox(provider config.Provider) *monitoring.Outbox {
+	return monitoring.NewDirectOutbox(provider)
+}
+
+// NewNotifier 创建多渠道通知器（dingtalk 及 notifiers 中已启用的渠道）
+func NewNotifier(provider config.Provider, outbox *monitoring.Outbox) monitoring2.Notifier {
+	return monitoring.NewNotifier(provider, outbox)
+}
+
+// NewSilencer 创建静默判断器（维护窗口与临时静默）
+func NewSilencer() monitoring2.Silencer {
+	return monitoring.NewSilencer()
+}
+
+// NewAlertHistory 创建告警历史（发送、恢复、静默与抑制的告警写入 history.file）
+func NewAlertHistory() monitoring2.AlertHistory {
+	return monitoring.NewBoltHistory()
 }
 
 // NewBasePolicy 创建基础告警策略
@@ -186,6 +284,16 @@ func NewHTTPPolicy() HTTPPolicy {
 	return monitoring.NewStatefulPolicy().(*monitoring.StatefulPolicy)
 }
 
+// NewPolicyStateStore 创建告警策略状态持久化（重启后恢复连续计数、防抖时间与未恢复的告警）
+func NewPolicyStateStore(provider config.Provider, policyBase BasePolicy, policyHTTP HTTPPolicy) *monitoring.PolicyStateStore {
+	return monitoring.NewPolicyStateStore(provider, (*monitoring.StatefulPolicy)(policyBase), (*monitoring.StatefulPolicy)(policyHTTP))
+}
+
+// NewFlapHistory 创建告警抖动记录查询（合并基础与 HTTP 告警策略）
+func NewFlapHistory(policyBase BasePolicy, policyHTTP HTTPPolicy) monitoring2.FlapHistory {
+	return monitoring.FlapHistories{(*monitoring.StatefulPolicy)(policyBase), (*monitoring.StatefulPolicy)(policyHTTP)}
+}
+
 // NewBaseMonitoringUseCase 创建基础监控用例
 func NewBaseMonitoringUseCase(
 	hostInfo collector.HostCollector,
@@ -196,6 +304,8 @@ func NewBaseMonitoringUseCase(
 	policy BasePolicy,
 	formatter monitoring2.Formatter,
 	notifier monitoring2.Notifier,
+	silencer monitoring2.Silencer,
+	history monitoring2.AlertHistory,
 ) BaseMonitoringUseCase {
 	return usecase.NewMonitoringUseCase(
 		hostInfo,
@@ -206,6 +316,8 @@ func NewBaseMonitoringUseCase(
 		(*monitoring.StatefulPolicy)(policy),
 		formatter,
 		notifier,
+		silencer,
+		history,
 	)
 }
 
@@ -219,6 +331,8 @@ func NewHTTPMonitoringUseCase(
 	policy HTTPPolicy,
 	formatter monitoring2.Formatter,
 	notifier monitoring2.Notifier,
+	silencer monitoring2.Silencer,
+	history monitoring2.AlertHistory,
 ) HTTPMonitoringUseCase {
 	return usecase.NewMonitoringUseCase(
 		hostInfo,
@@ -229,6 +343,8 @@ func NewHTTPMonitoringUseCase(
 		(*monitoring.StatefulPolicy)(policy),
 		formatter,
 		notifier,
+		silencer,
+		history,
 	)
 }
 
@@ -296,6 +412,9 @@ func NewScheduledPushUseCase(
 	alertStorage scheduled_push.ScheduledPushAlertStorage,
 	clientDataRepository scheduled_push.ClientDataRepository,
 	scheduledPushFormatter scheduled_push.ScheduledPushFormatter,
+	silencer monitoring2.Silencer,
+	flapHistory monitoring2.FlapHistory,
+	diskForecaster monitoring2.DiskForecaster,
 ) scheduled_push.ScheduledPushUseCase {
 	return usecase.NewScheduledPushUseCase(
 		hostInfo,
@@ -310,6 +429,9 @@ func NewScheduledPushUseCase(
 		alertStorage,
 		clientDataRepository,
 		scheduledPushFormatter,
+		silencer,
+		flapHistory,
+		diskForecaster,
 	)
 }
 
@@ -319,8 +441,8 @@ func NewClientDataRepository() scheduled_push.ClientDataRepository {
 }
 
 // NewScheduledPushFormatter 创建定时推送格式化器
-func NewScheduledPushFormatter() scheduled_push.ScheduledPushFormatter {
-	return monitoring.NewScheduledPushFormatter()
+func NewScheduledPushFormatter(provider config.Provider) scheduled_push.ScheduledPushFormatter {
+	return monitoring.NewScheduledPushFormatter(provider)
 }
 
 // NewScheduledPushAlertStorage 创建全局定时推送告警存储
@@ -358,7 +480,13 @@ func NewLoggerService(logger3 logger.Logger) *usecase.LoggerService {
 
 // App 应用程序结构体，包含所有需要的组件
 type App struct {
-	Config                 *entity.Config
+	Provider               config.Provider
+	Notifier               monitoring2.Notifier
+	Outbox                 monitoring2.Outbox
+	PolicyState            monitoring2.PolicyStateStore
+	Baselines              monitoring2.BaselineStore
+	Monitor                *usecase.MonitoringUseCase
+	ScheduledPushUseCase   scheduled_push.ScheduledPushUseCase
 	Coordinator            *usecase.Coordinator
 	TickerScheduler        ticker.TickerScheduler
 	ScheduledPushScheduler scheduled_push.ScheduledPushScheduler
@@ -369,24 +497,47 @@ type App struct {
 func (app *App) Start() error {
 	log.Println("开始监控...")
 
-	app.printMonitoringStatus()
+	app.printMonitoringStatus(app.Config())
+
+	app.PolicyState.Restore()
+
+	app.Baselines.Restore()
 
 	stopCh := make(chan struct{})
 	go app.handleSignals(stopCh)
 
+	go app.Outbox.Run(stopCh)
+
+	go app.PolicyState.Run(stopCh)
+
+	go app.Baselines.Run(stopCh)
+
+	app.Provider.Subscribe(app.applyConfig)
+	go app.Provider.Watch(stopCh, app.notifyReloadError)
+
 	if err := app.startSchedulers(stopCh); err != nil {
 		return err
 	}
 
-	app.Coordinator.RunWithIntervals(app.Config, stopCh)
+	app.Coordinator.RunWithIntervals(app.Config(), stopCh)
 	log.Println("GWatch 正在退出...")
+
+	if err := app.PolicyState.Save(); err != nil {
+		log.Printf("[ERROR] 保存告警策略状态失败: %v", err)
+	}
+	if err := app.Baselines.Save(); err != nil {
+		log.Printf("[ERROR] 保存异常检测基线失败: %v", err)
+	}
 	return nil
 }
 
-// printMonitoringStatus 打印监控状态
-func (app *App) printMonitoringStatus() {
-	cfg := app.Config
+// Config 返回当前生效的配置（热加载后为最新配置）
+func (app *App) Config() *entity.Config {
+	return app.Provider.GetConfig()
+}
 
+// printMonitoringStatus 打印监控状态
+func (app *App) printMonitoringStatus(cfg *entity.Config) {
 	if cfg.HostMonitoring != nil && cfg.HostMonitoring.Enabled {
 		log.Println("主机监控已启用，监控间隔:", cfg.HostMonitoring.Interval)
 	} else if cfg.HostMonitoring != nil && !cfg.HostMonitoring.Enabled {
@@ -395,14 +546,14 @@ func (app *App) printMonitoringStatus() {
 
 	if cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled {
 		log.Println("应用层监控已启用")
-		if cfg.AppMonitoring.Redis != nil && cfg.AppMonitoring.Redis.Enabled {
-			log.Println("  - Redis监控已启用")
-		} else if cfg.AppMonitoring.Redis != nil && !cfg.AppMonitoring.Redis.Enabled {
+		if targets := cfg.AppMonitoring.RedisTargets(); len(targets) > 0 {
+			log.Printf("  - Redis监控已启用，实例数: %d", len(targets))
+		} else if cfg.AppMonitoring.Redis != nil || len(cfg.AppMonitoring.RedisInstances) > 0 {
 			log.Println("  - Redis监控已禁用")
 		}
-		if cfg.AppMonitoring.MySQL != nil && cfg.AppMonitoring.MySQL.Enabled {
-			log.Println("  - MySQL监控已启用")
-		} else if cfg.AppMonitoring.MySQL != nil && !cfg.AppMonitoring.MySQL.Enabled {
+		if targets := cfg.AppMonitoring.MySQLTargets(); len(targets) > 0 {
+			log.Printf("  - MySQL监控已启用，实例数: %d", len(targets))
+		} else if cfg.AppMonitoring.MySQL != nil || len(cfg.AppMonitoring.MySQLInstances) > 0 {
 			log.Println("  - MySQL监控已禁用")
 		}
 		if cfg.AppMonitoring.HTTP != nil && cfg.AppMonitoring.HTTP.Enabled {
@@ -418,49 +569,112 @@ func (app *App) printMonitoringStatus() {
 	} else if cfg.AppMonitoring != nil && !cfg.AppMonitoring.Enabled {
 		log.Println("应用层监控已禁用")
 	}
+
+	if cfg.Outbox.IsEnabled() {
+		stats := app.Outbox.Stats()
+		log.Printf("通知发件箱已启用，待发送: %d 条，发送失败: %d 条", stats.Pending, stats.Failed)
+	} else {
+		log.Println("通知发件箱已禁用")
+	}
+
+	if cfg.PolicyState.IsEnabled() {
+		log.Printf("告警策略状态持久化已启用，状态文件: %s，保存间隔: %v", cfg.PolicyState.File, cfg.PolicyState.SaveInterval)
+	} else {
+		log.Println("告警策略状态持久化已禁用")
+	}
+
+	if cfg.History.IsEnabled() {
+		log.Printf("告警历史已启用，数据库文件: %s，保留: %v", cfg.History.File, cfg.History.Retention)
+	} else {
+		log.Println("告警历史已禁用")
+	}
+
+	if a := cfg.Anomaly; a != nil {
+		log.Printf("动态基线异常检测已启用，指标: %v，阈值: %.1fσ，持续: %v，基线文件: %s", a.Metrics, a.Sigma, a.For, a.File)
+	} else {
+		log.Println("动态基线异常检测未启用")
+	}
 }
 
-// handleSignals 处理系统信号
+// handleSignals 处理系统信号：SIGHUP 重新加载配置，SIGINT/SIGTERM 优雅退出
 func (app *App) handleSignals(stopCh chan struct{}) {
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	sig := <-c
-	log.Printf("接收到信号 %v，正在优雅退出...\n", sig)
-	close(stopCh)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range c {
+		if sig == syscall.SIGHUP {
+			log.Println("接收到 SIGHUP 信号，重新加载配置...")
+			if err := app.Provider.Reload(); err != nil {
+				log.Printf("[ERROR] %v", err)
+				app.notifyReloadError(err)
+			}
+			continue
+		}
+		log.Printf("接收到信号 %v，正在优雅退出...\n", sig)
+		close(stopCh)
+		return
+	}
 }
 
-// startSchedulers 启动所有调度器
-func (app *App) startSchedulers(stopCh <-chan struct{}) error {
-	cfg := app.Config
+// applyConfig 将热加载后的配置同步到协调器和各调度器，告警策略状态保持不变
+func (app *App) applyConfig(cfg *entity.Config) {
+	app.Coordinator.UpdateConfig(cfg)
+	app.TickerScheduler.UpdateConfig(cfg)
+	app.ScheduledPushScheduler.UpdateConfig(cfg)
+	app.printMonitoringStatus(cfg)
+}
 
-	if cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled && cfg.AppMonitoring.Tickers != nil && cfg.AppMonitoring.Tickers.Enabled && len(cfg.AppMonitoring.Tickers.TickerInterfaces) > 0 {
-		log.Println("启动定时器调度器...")
-		if err := app.TickerScheduler.Start(cfg, stopCh); err != nil {
-			log.Printf("启动定时器调度器失败: %v", err)
-			return err
-		}
+// notifyReloadError 配置热加载失败时发送钉钉通知
+func (app *App) notifyReloadError(err error) {
+	if app.Notifier == nil {
+		return
+	}
+	title := "GWatch 配置热加载失败"
+	markdown := fmt.Sprintf("### %s\n\n- 错误: %v\n- 处理: 已拒绝新配置，继续使用旧配置运行\n", title, err)
+	if sendErr := app.Notifier.Send(title, markdown); sendErr != nil {
+		log.Printf("发送配置热加载失败通知失败: %v", sendErr)
 	}
+}
 
-	if cfg.ScheduledPush != nil && cfg.ScheduledPush.Enabled {
-		log.Println("启动全局定时推送调度器...")
-		if err := app.ScheduledPushScheduler.Start(cfg, stopCh); err != nil {
-			log.Printf("启动全局定时推送调度器失败: %v", err)
-			return err
-		}
+// startSchedulers 启动所有调度器
+// 调度器总是启动，是否执行由每次检查时的最新配置决定，以便热加载开启/关闭功能
+func (app *App) startSchedulers(stopCh <-chan struct{}) error {
+	cfg := app.Config()
+	log.Println("启动定时器调度器...")
+	if err := app.TickerScheduler.Start(cfg, stopCh); err != nil {
+		log.Printf("启动定时器调度器失败: %v", err)
+		return err
+	}
+	log.Println("启动全局定时推送调度器...")
+	if err := app.ScheduledPushScheduler.Start(cfg, stopCh); err != nil {
+		log.Printf("启动全局定时推送调度器失败: %v", err)
+		return err
 	}
 
 	return nil
 }
 
 // NewApp 创建应用程序实例
-func NewApp(config2 *entity.Config,
+func NewApp(
+	provider config.Provider,
+	notifier monitoring2.Notifier,
+	outbox *monitoring.Outbox,
+	policyState *monitoring.PolicyStateStore,
+	baselines *monitoring.AnomalyEvaluator,
+	monitor BaseMonitoringUseCase,
+	scheduledPushUseCase scheduled_push.ScheduledPushUseCase,
 	coordinator *usecase.Coordinator,
 	tickerScheduler ticker.TickerScheduler,
 	scheduledPushScheduler scheduled_push.ScheduledPushScheduler,
 	loggerService *usecase.LoggerService,
 ) *App {
 	return &App{
-		Config:                 config2,
+		Provider:               provider,
+		Notifier:               notifier,
+		Outbox:                 outbox,
+		PolicyState:            policyState,
+		Baselines:              baselines,
+		Monitor:                (*usecase.MonitoringUseCase)(monitor),
+		ScheduledPushUseCase:   scheduledPushUseCase,
 		Coordinator:            coordinator,
 		TickerScheduler:        tickerScheduler,
 		ScheduledPushScheduler: scheduledPushScheduler,
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"log"
+	"os"
+	"time"
+)
+
+// alertEvent 由告警决策生成告警历史记录，message 为空时使用告警中文名
+func alertEvent(event string, d domainMonitor.Decision, message, silencedBy string) entity.AlertEvent {
+	if message == "" {
+		message = d.Message
+	}
+	if message == "" {
+		message = d.Type.Describe(d.Target)
+	}
+	severity := d.Severity
+	if severity == "" {
+		severity = entity.SeverityWarning
+	}
+	return entity.AlertEvent{
+		Time:       time.Now(),
+		Event:      event,
+		Type:       d.Type,
+		Severity:   severity,
+		Target:     d.Target,
+		Value:      d.Value,
+		Message:    message,
+		SilencedBy: silencedBy,
+		Escalated:  d.Escalated,
+		Flapping:   d.Flapping,
+	}
+}
+
+// recoveryEvent 由恢复事件生成告警历史记录，Value 为告警期间的峰值
+func recoveryEvent(r domainMonitor.Recovery) entity.AlertEvent {
+	message := r.Record.Message
+	if message == "" {
+		message = r.Type.Describe(r.Target)
+	}
+	return entity.AlertEvent{
+		Time:       time.Now(),
+		Event:      entity.AlertEventResolved,
+		Type:       r.Type,
+		Severity:   r.Record.Severity,
+		Target:     r.Target,
+		Value:      r.Peak,
+		Message:    message,
+		SilencedBy: r.Record.SilencedBy,
+		Escalated:  r.Record.EscalatedAt != nil,
+		FiredAt:    r.Record.Timestamp,
+		Duration:   r.Duration(),
+	}
+}
+
+// recordHistory 写入告警历史并补充本机主机名与IP；写入失败只记录日志，不影响通知
+func (useCase *MonitoringUseCase) recordHistory(config *entity.Config, events []entity.AlertEvent) {
+	if useCase.history == nil || len(events) == 0 || !config.History.IsEnabled() {
+		return
+	}
+	host, _ := os.Hostname()
+	ip, _ := utils.GetLocalIP()
+	for i := range events {
+		events[i].Host, events[i].IP = host, ip
+	}
+	if err := useCase.history.Record(config, events...); err != nil {
+		log.Printf("[ERROR] 写入告警历史失败: %v", err)
+	}
+}
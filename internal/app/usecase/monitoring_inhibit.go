@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"log"
+)
+
+// inhibitedBy 返回抑制该告警的规则：firing 中存在命中 source_types 且与之关联（同一对象或同一主机）的其他告警
+func inhibitedBy(rules []entity.InhibitRule, firing []domainMonitor.Decision, d domainMonitor.Decision) (*entity.InhibitRule, *domainMonitor.Decision) {
+	for i := range rules {
+		r := &rules[i]
+		if !utils.MatchAny(r.TargetTypes, string(d.Type)) {
+			continue
+		}
+		for j := range firing {
+			src := &firing[j]
+			if src.Type == d.Type && src.Target == d.Target {
+				continue // 告警不抑制自身
+			}
+			if !utils.MatchAny(r.SourceTypes, string(src.Type)) {
+				continue
+			}
+			if r.Equal == entity.InhibitEqualHost || src.Target == d.Target {
+				return r, src
+			}
+		}
+	}
+	return nil, nil
+}
+
+// InhibitAlerts 在告警策略之后、发送通知之前应用 inhibit_rules：firing 为本周期全部命中的决策，
+// alerts 为准备发送的告警；被抑制的告警在告警记录中标记抑制规则（与静默相同，恢复时不发送恢复通知），不再发送
+func (useCase *MonitoringUseCase) InhibitAlerts(config *entity.Config, firing, alerts []domainMonitor.Decision) []domainMonitor.Decision {
+	return useCase.inhibitAlerts(config, firing, alerts, useCase.ownPolicy)
+}
+
+// inhibitAlerts 同 InhibitAlerts，被抑制的告警由 policyOf 返回的告警策略（产生该告警的策略）标记
+func (useCase *MonitoringUseCase) inhibitAlerts(config *entity.Config, firing, alerts []domainMonitor.Decision, policyOf policyOf) []domainMonitor.Decision {
+	if len(config.InhibitRules) == 0 || len(alerts) == 0 {
+		return alerts
+	}
+	result := alerts[:0:0]
+	var events []entity.AlertEvent
+	for _, d := range alerts {
+		r, src := inhibitedBy(config.InhibitRules, firing, d)
+		if r == nil {
+			result = append(result, d)
+			continue
+		}
+		log.Printf("[抑制] %s 被 %s 抑制（规则 %s），已记录但不发送", d.Type.Describe(d.Target), src.Type.Describe(src.Target), r.Name)
+		policyOf(d).MarkSilenced(d, entity.InhibitID(r.Name))
+		events = append(events, alertEvent(entity.AlertEventInhibited, d, "", entity.InhibitID(r.Name)))
+	}
+	useCase.recordHistory(config, events)
+	return result
+}
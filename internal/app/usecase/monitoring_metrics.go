@@ -7,8 +7,10 @@ import (
 	"GWatch/internal/entity"
 	"GWatch/internal/utils"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -24,21 +26,23 @@ type MonitoringUseCase struct {
 	alertPolicy     domainAlert.Policy
 	alertFormatter  domainAlert.Formatter
 	alertNotifier   Notifier
-	isRedisInited   bool
-	isMySQLInited   bool
-	isHTTPInited    bool
+	silencer        domainMonitor.Silencer
+	history         domainMonitor.AlertHistory
+	connMu          sync.Mutex
+	connInited      map[string]bool // 按 "类型:实例名称" 记录 Redis/MySQL/HTTP 采集器是否已初始化，HTTP 的实例名称为空
 }
 
-// RedisClient 是 redis 操作接口
+// RedisClient 是 redis 操作接口，按实例名称区分多个 Redis（空名称为 redis 单实例）
 type RedisClient interface {
-	Init() error
-	GetClients() (int, error)
-	GetClientsDetail() ([]entity.ClientInfo, error)
+	Init(name string) error
+	GetClients(name string) (int, error)
+	GetClientsDetail(name string) ([]entity.ClientInfo, error)
 }
 
 // Notifier 发送告警通知
 type Notifier interface {
 	Send(title, markdown string) error
+	SendTo(channels, atMobiles []string, title, markdown string) error
 }
 
 // NewMonitoringUseCase 创建监控用例
@@ -51,6 +55,8 @@ func NewMonitoringUseCase(
 	alertPolicy domainAlert.Policy,
 	alertFormatter domainAlert.Formatter,
 	alertNotifier Notifier,
+	silencer domainMonitor.Silencer,
+	history domainMonitor.AlertHistory,
 ) *MonitoringUseCase {
 	return &MonitoringUseCase{
 		hostCollector:  hostCollector,
@@ -61,9 +67,27 @@ func NewMonitoringUseCase(
 		alertPolicy:    alertPolicy,
 		alertFormatter: alertFormatter,
 		alertNotifier:  alertNotifier,
+		silencer:       silencer,
+		history:        history,
 	}
 }
 
+// policyOf 返回负责某条告警的告警策略，告警在该策略中记录静默、抑制与撤销；
+// 合并通知时一次发送两个周期的告警，由协调器按告警来源指定
+type policyOf func(d domainMonitor.Decision) domainMonitor.Policy
+
+// ownPolicy 单周期通知时所有告警都由本用例的告警策略产生
+func (useCase *MonitoringUseCase) ownPolicy(domainMonitor.Decision) domainMonitor.Policy {
+	return useCase.alertPolicy
+}
+
+// ResetConnections 重置采集器初始化状态，下一次采集时按最新配置重新初始化连接（配置热加载后调用）
+func (useCase *MonitoringUseCase) ResetConnections() {
+	useCase.connMu.Lock()
+	useCase.connInited = nil
+	useCase.connMu.Unlock()
+}
+
 // Run 执行一次完整的监控流程
 func (useCase *MonitoringUseCase) Run(config *entity.Config) error {
 	// 1. 采集指标
@@ -76,200 +100,21 @@ func (useCase *MonitoringUseCase) Run(config *entity.Config) error {
 	return useCase.EvaluateAndNotify(config, metrics)
 }
 
+// CollectOnce 采集一次全部指标（基础指标 + HTTP 接口）
 func (useCase *MonitoringUseCase) CollectOnce(config *entity.Config) *entity.SystemMetrics {
-	metrics := &entity.SystemMetrics{Timestamp: time.Now()}
-
-	// 主机类监控：只有当host_monitoring配置存在且启用时才执行
-	if config != nil && config.HostMonitoring != nil && config.HostMonitoring.Enabled {
-		// CPU监控
-		metrics.CPU.Percent, metrics.CPU.Error = useCase.hostCollector.GetCPUPercent()
-		
-		// 内存监控
-		metrics.Memory.Percent, metrics.Memory.UsedMB, metrics.Memory.TotalMB, metrics.Memory.Error = useCase.hostCollector.GetMemoryUsage()
-		
-		// 磁盘监控
-		metrics.Disk.Percent, metrics.Disk.UsedGB, metrics.Disk.TotalGB, metrics.Disk.Error = useCase.hostCollector.GetDiskUsage()
-		metrics.Disk.ReadKBps, metrics.Disk.WriteKBps, _ = useCase.hostCollector.GetDiskIORate()
-		
-		// 网络监控
-		metrics.Network.DownloadKBps, metrics.Network.UploadKBps, metrics.Network.Error = useCase.hostCollector.GetNetworkRate()
-	}
-
-	// 应用层类监控：只有当app_monitoring配置存在且启用时才执行
-	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled {
-		// Redis监控：只有当Redis配置存在且启用时才监控
-		if config.AppMonitoring.Redis != nil && config.AppMonitoring.Redis.Enabled {
-		if !useCase.isRedisInited {
-			if err := useCase.redisClient.Init(); err != nil {
-				metrics.Redis.ConnectionError = err
-			} else {
-				useCase.isRedisInited = true
-			}
-		}
-
-		if useCase.isRedisInited {
-			clientCount, err := useCase.redisClient.GetClients()
-			if err != nil {
-				metrics.Redis.ConnectionError = err
-			} else {
-				metrics.Redis.ClientCount = clientCount
-			}
-			metrics.Redis.ClientDetails, metrics.Redis.DetailError = useCase.redisClient.GetClientsDetail()
-		}
-		}
-
-		// MySQL监控：只有当MySQL配置存在且启用时才监控
-		if config.AppMonitoring.MySQL != nil && config.AppMonitoring.MySQL.Enabled {
-			if !useCase.isMySQLInited {
-				if err := useCase.mysqlCollector.Init(); err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					useCase.isMySQLInited = true
-				}
-			}
-
-			if useCase.isMySQLInited {
-				// 收集MySQL连接指标
-				mysqlMetrics, err := useCase.mysqlCollector.GetConnectionMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.Connections = entity.ConnectionMetrics{
-						ThreadsConnected:    mysqlMetrics.ThreadsConnected,
-						ThreadsRunning:      mysqlMetrics.ThreadsRunning,
-						MaxConnections:      mysqlMetrics.MaxConnections,
-						ConnectionErrors:    mysqlMetrics.ConnectionErrors,
-						AbortedConnects:     mysqlMetrics.AbortedConnects,
-						ConnectionUsage:     mysqlMetrics.ConnectionUsage,
-					}
-				}
-
-				// 收集MySQL查询性能指标
-				queryMetrics, err := useCase.mysqlCollector.GetQueryPerformanceMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.QueryPerformance = entity.QueryPerformanceMetrics{
-						QPS:             queryMetrics.QPS,
-						TPS:             queryMetrics.TPS,
-						SlowQueries:     queryMetrics.SlowQueries,
-						P95ResponseTime: queryMetrics.P95ResponseTime,
-						P99ResponseTime: queryMetrics.P99ResponseTime,
-						Questions:       queryMetrics.Questions,
-						Committed:       queryMetrics.Committed,
-						RolledBack:      queryMetrics.RolledBack,
-					}
-				}
-
-				// 收集MySQL Buffer Pool指标
-				bufferPoolMetrics, err := useCase.mysqlCollector.GetBufferPoolMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.BufferPool = entity.BufferPoolMetrics{
-						HitRate:       bufferPoolMetrics.HitRate,
-						Usage:         bufferPoolMetrics.Usage,
-						PagesTotal:    bufferPoolMetrics.PagesTotal,
-						PagesData:     bufferPoolMetrics.PagesData,
-						PagesFree:     bufferPoolMetrics.PagesFree,
-						PagesDirty:    bufferPoolMetrics.PagesDirty,
-						ReadRequests:  bufferPoolMetrics.ReadRequests,
-						Reads:         bufferPoolMetrics.Reads,
-						WriteRequests: bufferPoolMetrics.WriteRequests,
-						Writes:        bufferPoolMetrics.Writes,
-					}
-				}
-
-				// 收集MySQL锁指标
-				lockMetrics, err := useCase.mysqlCollector.GetLockMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.Locks = entity.LockMetrics{
-						RowLockWaits: lockMetrics.RowLockWaits,
-						RowLockTime:  lockMetrics.RowLockTime,
-						Deadlocks:    lockMetrics.Deadlocks,
-					}
-				}
-
-				// 收集MySQL事务指标
-				transactionMetrics, err := useCase.mysqlCollector.GetTransactionMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.Transactions = entity.TransactionMetrics{
-						UncommittedTransactions: transactionMetrics.UncommittedTransactions,
-						BinlogGrowthRate:        transactionMetrics.BinlogGrowthRate,
-					}
-				}
-
-				// 收集MySQL复制指标（如果启用）
-				if config.AppMonitoring.MySQL.Replication != nil && config.AppMonitoring.MySQL.Replication.Enabled {
-					replicationMetrics, err := useCase.mysqlCollector.GetReplicationMetrics(context.Background())
-					if err != nil {
-						metrics.MySQL.Error = err
-					} else {
-						metrics.MySQL.Replication = &entity.ReplicationMetrics{
-							SlaveIORunning:        replicationMetrics.SlaveIORunning,
-							SlaveSQLRunning:       replicationMetrics.SlaveSQLRunning,
-							SecondsBehindMaster:   replicationMetrics.SecondsBehindMaster,
-							MasterLogFile:         replicationMetrics.MasterLogFile,
-							ReadMasterLogPos:      replicationMetrics.ReadMasterLogPos,
-							RelayLogFile:          replicationMetrics.RelayLogFile,
-							RelayLogPos:           replicationMetrics.RelayLogPos,
-							GTIDMode:              replicationMetrics.GTIDMode,
-							GTIDExecuted:          replicationMetrics.GTIDExecuted,
-						}
-					}
-				}
-			}
-		}
-
-		// HTTP接口监控：只有当HTTP配置存在且启用时才监控
-		if config.AppMonitoring.HTTP != nil && config.AppMonitoring.HTTP.Enabled {
-			if !useCase.isHTTPInited {
-				if err := useCase.httpCollector.Init(); err != nil {
-					metrics.HTTP.Error = err
-				} else {
-					useCase.isHTTPInited = true
-				}
-			}
-
-			if useCase.isHTTPInited {
-				var httpInterfaces []entity.HTTPInterfaceMetrics
-				if config.AppMonitoring.HTTP.Interfaces != nil {
-					for _, httpConfig := range config.AppMonitoring.HTTP.Interfaces {
-						isAccessible, responseTime, statusCode, err := useCase.httpCollector.CheckInterface(httpConfig.URL, httpConfig.Timeout)
-
-						httpInterfaces = append(httpInterfaces, entity.HTTPInterfaceMetrics{
-							Name:         httpConfig.Name,
-							URL:          httpConfig.URL,
-							IsAccessible: isAccessible,
-							ResponseTime: responseTime,
-							StatusCode:   statusCode,
-							Error:        err,
-							NeedAlert:    httpConfig.NeedAlert,
-							AllowedCodes: httpConfig.AllowedCodes,
-						})
-					}
-				}
-				metrics.HTTP.Interfaces = httpInterfaces
-			}
-		}
-	}
-
-	return metrics
+	return CombineMetrics(useCase.CollectBaseOnce(config), useCase.CollectHTTPOnce(config))
 }
 
 func (useCase *MonitoringUseCase) EvaluateAndNotify(config *entity.Config, metrics *entity.SystemMetrics) error {
 	decisions, _ := useCase.evaluator.Evaluate(config, metrics)
-	alertTypes := useCase.alertPolicy.Apply(config, metrics, decisions)
-	if len(alertTypes) == 0 {
+	triggered := useCase.alertPolicy.Apply(config, metrics, decisions)
+	_ = useCase.NotifyRecoveries(config, metrics, useCase.alertPolicy.Resolve(config, decisions))
+	if len(triggered) == 0 {
 		return nil
 	}
 
 	// 👇 直接委托给 NotifyWithAlertTypes —— 不再自己处理告警构造！
-	return useCase.NotifyWithAlertTypes(config, metrics, alertTypes)
+	return useCase.NotifyWithAlertTypes(config, metrics, useCase.InhibitAlerts(config, decisions, triggered))
 }
 
 func (useCase *MonitoringUseCase) EvaluateAndNotifyBaseOnly(config *entity.Config, metrics *entity.SystemMetrics) error {
@@ -280,8 +125,9 @@ func (useCase *MonitoringUseCase) EvaluateAndNotifyBaseOnly(config *entity.Confi
 			filteredDecisions = append(filteredDecisions, decision)
 		}
 	}
-	alertTypes := useCase.alertPolicy.Apply(config, metrics, filteredDecisions)
-	return useCase.NotifyWithAlertTypes(config, metrics, alertTypes) // ← 统一出口
+	triggered := useCase.alertPolicy.Apply(config, metrics, filteredDecisions)
+	_ = useCase.NotifyRecoveries(config, metrics, useCase.alertPolicy.Resolve(config, filteredDecisions))
+	return useCase.NotifyWithAlertTypes(config, metrics, useCase.InhibitAlerts(config, decisions, triggered)) // ← 统一出口
 }
 
 func (useCase *MonitoringUseCase) EvaluateAndNotifyHTTPOnly(config *entity.Config, metrics *entity.SystemMetrics) error {
@@ -292,8 +138,9 @@ func (useCase *MonitoringUseCase) EvaluateAndNotifyHTTPOnly(config *entity.Confi
 			filteredDecisions = append(filteredDecisions, decision)
 		}
 	}
-	alertTypes := useCase.alertPolicy.Apply(config, metrics, filteredDecisions)
-	return useCase.NotifyWithAlertTypes(config, metrics, alertTypes) // ← 统一出口
+	triggered := useCase.alertPolicy.Apply(config, metrics, filteredDecisions)
+	_ = useCase.NotifyRecoveries(config, metrics, useCase.alertPolicy.Resolve(config, filteredDecisions))
+	return useCase.NotifyWithAlertTypes(config, metrics, useCase.InhibitAlerts(config, decisions, triggered)) // ← 统一出口
 }
 
 // PrintMetrics 仅用于本地观察，不属于核心业务
@@ -319,37 +166,55 @@ func (useCase *MonitoringUseCase) PrintMetrics(config *entity.Config, metrics *e
 			log.Printf("磁盘使用: %.2f%% (%d/%d GB)\n",
 				metrics.Disk.Percent, metrics.Disk.UsedGB, metrics.Disk.TotalGB)
 		}
+		if metrics.Disk.MountsError != nil {
+			log.Println("挂载点监控失败:", metrics.Disk.MountsError.Error())
+		}
+		for _, mount := range metrics.Disk.Mounts {
+			log.Printf("挂载点 %s: %.2f%% (%d/%d GB), inode %.2f%%\n",
+				mount.Mount, mount.Percent, mount.UsedGB, mount.TotalGB, mount.InodesPercent)
+		}
 		if metrics.Network.Error != nil {
 			log.Println("网络监控失败:", metrics.Network.Error.Error())
 		} else {
 			log.Printf("网络: 下载 %.2f KB/s | 上传 %.2f KB/s\n", metrics.Network.DownloadKBps, metrics.Network.UploadKBps)
 		}
+		if metrics.Network.InterfacesError != nil {
+			log.Println("网卡监控失败:", metrics.Network.InterfacesError.Error())
+		}
+		for _, itf := range metrics.Network.Interfaces {
+			log.Printf("网卡 %s: 下载 %.2f KB/s | 上传 %.2f KB/s | 错误 %.2f/s | 丢包 %.2f/s\n",
+				itf.Name, itf.RecvKBps, itf.SentKBps, itf.ErrorRate(), itf.DropRate())
+		}
 		log.Printf("磁盘IO: 读 %.2f KB/s | 写 %.2f KB/s\n", metrics.Disk.ReadKBps, metrics.Disk.WriteKBps)
 	}
 
-	// Redis监控信息 - 只有当app_monitoring和redis配置存在且启用时才显示
-	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled && config.AppMonitoring.Redis != nil && config.AppMonitoring.Redis.Enabled {
-		if metrics.Redis.ConnectionError != nil {
-			log.Println("Redis 连接失败:", metrics.Redis.ConnectionError.Error())
+	// Redis监控信息 - 每个已启用的实例一行
+	for _, r := range metrics.Redis {
+		label := entity.TargetLabel("Redis", r.Name)
+		if r.ConnectionError != nil {
+			log.Printf("%s 连接失败: %v\n", label, r.ConnectionError)
 		} else {
-			log.Printf("Redis 连接数: %d\n", metrics.Redis.ClientCount)
+			log.Printf("%s 连接数: %d\n", label, r.ClientCount)
 		}
 	}
 
-	// MySQL监控信息 - 只有当app_monitoring和mysql配置存在且启用时才显示
-	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled && config.AppMonitoring.MySQL != nil && config.AppMonitoring.MySQL.Enabled {
-		if metrics.MySQL.Error != nil {
-			log.Println("MySQL 连接失败:", metrics.MySQL.Error.Error())
+	// MySQL监控信息 - 每个已启用的实例分别显示
+	for _, r := range metrics.MySQL {
+		label := entity.TargetLabel("MySQL", r.Name)
+		if r.Error != nil {
+			log.Printf("%s 连接失败: %v\n", label, r.Error)
 		} else {
-			log.Printf("MySQL 连接数: %d/%d (%.2f%%)\n", 
-				metrics.MySQL.Connections.ThreadsConnected, 
-				metrics.MySQL.Connections.MaxConnections,
-				metrics.MySQL.Connections.ConnectionUsage)
-			log.Printf("MySQL QPS: %d, 慢查询: %d\n", 
-				metrics.MySQL.QueryPerformance.QPS,
-				metrics.MySQL.QueryPerformance.SlowQueries)
-			log.Printf("MySQL Buffer Pool 命中率: %.2f%%\n", 
-				metrics.MySQL.BufferPool.HitRate)
+			log.Printf("%s 连接数: %d/%d (%.2f%%)\n", label,
+				r.Connections.ThreadsConnected,
+				r.Connections.MaxConnections,
+				r.Connections.ConnectionUsage)
+			log.Printf("%s QPS: %.1f, TPS: %.1f, 慢查询: %.0f 条/分钟, 死锁: %.0f 次/小时\n", label,
+				r.QueryPerformance.QueriesPerSecond,
+				r.QueryPerformance.TransactionsPerSecond,
+				r.QueryPerformance.SlowQueriesPerMinute,
+				r.Locks.DeadlocksPerHour)
+			log.Printf("%s Buffer Pool 命中率: %.2f%%\n", label,
+				r.BufferPool.HitRate)
 		}
 	}
 
@@ -420,134 +285,179 @@ func (useCase *MonitoringUseCase) CollectBaseOnce(config *entity.Config) *entity
 		metrics.CPU.Percent, metrics.CPU.Error = useCase.hostCollector.GetCPUPercent()
 		metrics.Memory.Percent, metrics.Memory.UsedMB, metrics.Memory.TotalMB, metrics.Memory.Error = useCase.hostCollector.GetMemoryUsage()
 		metrics.Disk.Percent, metrics.Disk.UsedGB, metrics.Disk.TotalGB, metrics.Disk.Error = useCase.hostCollector.GetDiskUsage()
+		metrics.Disk.Mounts, metrics.Disk.MountsError = useCase.hostCollector.GetMountUsages(config.HostMonitoring.Disks)
 		metrics.Disk.ReadKBps, metrics.Disk.WriteKBps, _ = useCase.hostCollector.GetDiskIORate()
 		metrics.Network.DownloadKBps, metrics.Network.UploadKBps, metrics.Network.Error = useCase.hostCollector.GetNetworkRate()
+		metrics.Network.Interfaces, metrics.Network.InterfacesError = useCase.hostCollector.GetInterfaceRates(config.HostMonitoring.Network)
+	}
+
+	// Redis监控：只有当app_monitoring存在且启用时，逐个采集已启用的Redis实例
+	if config != nil {
+		metrics.Redis = useCase.collectRedis(config)
 	}
 
-	// Redis监控：只有当app_monitoring存在且启用，Redis配置存在且启用时才执行
-	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled && config.AppMonitoring.Redis != nil && config.AppMonitoring.Redis.Enabled {
-		if !useCase.isRedisInited {
-			if err := useCase.redisClient.Init(); err != nil {
-				metrics.Redis.ConnectionError = err
+	// MySQL监控：只有当app_monitoring存在且启用时，逐个采集已启用的MySQL实例
+	if config != nil {
+		metrics.MySQL = useCase.collectMySQL(config)
+	}
+
+	return metrics
+}
+
+// collectRedis 逐个采集已启用的 Redis 实例，各实例独立初始化连接，一个实例失败不影响其他实例
+func (useCase *MonitoringUseCase) collectRedis(config *entity.Config) []entity.RedisMetrics {
+	var result []entity.RedisMetrics
+	for _, target := range config.AppMonitoring.RedisTargets() {
+		m := entity.RedisMetrics{Name: target.Name, Addr: target.Addr}
+
+		inited := useCase.isConnInited("redis", target.Name)
+		if !inited {
+			if err := useCase.redisClient.Init(target.Name); err != nil {
+				m.ConnectionError = err
 			} else {
-				useCase.isRedisInited = true
+				inited = true
+				useCase.setConnInited("redis", target.Name, true)
 			}
 		}
 
-		if useCase.isRedisInited {
-			clientCount, err := useCase.redisClient.GetClients()
+		if inited {
+			clientCount, err := useCase.redisClient.GetClients(target.Name)
 			if err != nil {
-				metrics.Redis.ConnectionError = err
+				m.ConnectionError = err
 			} else {
-				metrics.Redis.ClientCount = clientCount
+				m.ClientCount = clientCount
 			}
-			metrics.Redis.ClientDetails, metrics.Redis.DetailError = useCase.redisClient.GetClientsDetail()
+			m.ClientDetails, m.DetailError = useCase.redisClient.GetClientsDetail(target.Name)
 		}
+		result = append(result, m)
 	}
+	return result
+}
 
-	// MySQL监控：只有当app_monitoring存在且启用，MySQL配置存在且启用时才执行
-	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled && config.AppMonitoring.MySQL != nil && config.AppMonitoring.MySQL.Enabled {
-		if !useCase.isMySQLInited {
-			if err := useCase.mysqlCollector.Init(); err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				useCase.isMySQLInited = true
-			}
+// collectMySQL 逐个采集已启用的 MySQL 实例，各实例独立初始化与重连，一个实例失败不影响其他实例
+func (useCase *MonitoringUseCase) collectMySQL(config *entity.Config) []entity.MySQLMetrics {
+	var result []entity.MySQLMetrics
+	for _, target := range config.AppMonitoring.MySQLTargets() {
+		result = append(result, useCase.collectMySQLInstance(target))
+	}
+	return result
+}
+
+// collectMySQLInstance 采集单个 MySQL 实例；连接指标采集失败时标记为未初始化，下一轮重新建立连接
+func (useCase *MonitoringUseCase) collectMySQLInstance(target entity.MySQLMonitoringConfig) entity.MySQLMetrics {
+	metrics := entity.MySQLMetrics{
+		Name: target.Name,
+		Addr: fmt.Sprintf("%s:%d", target.Host, target.Port),
+		Role: target.Role,
+	}
+	name := target.Name
+
+	if !useCase.isConnInited("mysql", name) {
+		if err := useCase.mysqlCollector.Init(name); err != nil {
+			metrics.Error = err
+			return metrics
 		}
+		useCase.setConnInited("mysql", name, true)
+	}
 
-		if useCase.isMySQLInited {
-			// 收集MySQL连接指标
-			mysqlMetrics, err := useCase.mysqlCollector.GetConnectionMetrics(context.Background())
-			if err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				metrics.MySQL.Connections = entity.ConnectionMetrics{
-					ThreadsConnected:    mysqlMetrics.ThreadsConnected,
-					ThreadsRunning:      mysqlMetrics.ThreadsRunning,
-					MaxConnections:      mysqlMetrics.MaxConnections,
-					ConnectionErrors:    mysqlMetrics.ConnectionErrors,
-					AbortedConnects:     mysqlMetrics.AbortedConnects,
-					ConnectionUsage:     mysqlMetrics.ConnectionUsage,
-				}
-			}
+	ctx := context.Background()
 
-			// 收集MySQL查询性能指标
-			queryMetrics, err := useCase.mysqlCollector.GetQueryPerformanceMetrics(context.Background())
-			if err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				metrics.MySQL.QueryPerformance = entity.QueryPerformanceMetrics{
-					QPS:             queryMetrics.QPS,
-					TPS:             queryMetrics.TPS,
-					SlowQueries:     queryMetrics.SlowQueries,
-					P95ResponseTime: queryMetrics.P95ResponseTime,
-					P99ResponseTime: queryMetrics.P99ResponseTime,
-					Questions:       queryMetrics.Questions,
-					Committed:       queryMetrics.Committed,
-					RolledBack:      queryMetrics.RolledBack,
-				}
-			}
+	// 收集MySQL连接指标
+	mysqlMetrics, err := useCase.mysqlCollector.GetConnectionMetrics(ctx, name)
+	if err != nil {
+		metrics.Error = err
+		useCase.setConnInited("mysql", name, false)
+		return metrics
+	}
+	metrics.Connections = entity.ConnectionMetrics{
+		ThreadsConnected: mysqlMetrics.ThreadsConnected,
+		ThreadsRunning:   mysqlMetrics.ThreadsRunning,
+		MaxConnections:   mysqlMetrics.MaxConnections,
+		ConnectionErrors: mysqlMetrics.ConnectionErrors,
+		AbortedConnects:  mysqlMetrics.AbortedConnects,
+		ConnectionUsage:  mysqlMetrics.ConnectionUsage,
+	}
 
-			// 收集MySQL Buffer Pool指标
-			bufferPoolMetrics, err := useCase.mysqlCollector.GetBufferPoolMetrics(context.Background())
-			if err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				metrics.MySQL.BufferPool = entity.BufferPoolMetrics{
-					HitRate:       bufferPoolMetrics.HitRate,
-					Usage:         bufferPoolMetrics.Usage,
-					PagesTotal:    bufferPoolMetrics.PagesTotal,
-					PagesData:     bufferPoolMetrics.PagesData,
-					PagesFree:     bufferPoolMetrics.PagesFree,
-					PagesDirty:    bufferPoolMetrics.PagesDirty,
-					ReadRequests:  bufferPoolMetrics.ReadRequests,
-					Reads:         bufferPoolMetrics.Reads,
-					WriteRequests: bufferPoolMetrics.WriteRequests,
-					Writes:        bufferPoolMetrics.Writes,
-				}
-			}
+	// 收集MySQL查询性能指标
+	queryMetrics, err := useCase.mysqlCollector.GetQueryPerformanceMetrics(ctx, name)
+	if err != nil {
+		metrics.Error = err
+	} else {
+		metrics.QueryPerformance = entity.QueryPerformanceMetrics{
+			QPS:             int(math.Round(queryMetrics.QPS)),
+			TPS:             int(math.Round(queryMetrics.TPS)),
+			QueriesPerSecond:      queryMetrics.QPS,
+			TransactionsPerSecond: queryMetrics.TPS,
+			SlowQueries:     queryMetrics.SlowQueries,
+			SlowQueriesPerMinute: queryMetrics.SlowQueriesPerMinute,
+			P95ResponseTime: queryMetrics.P95ResponseTime,
+			P99ResponseTime: queryMetrics.P99ResponseTime,
+			Questions:       queryMetrics.Questions,
+			Committed:       queryMetrics.Committed,
+			RolledBack:      queryMetrics.RolledBack,
+		}
+	}
 
-			// 收集MySQL锁指标
-			lockMetrics, err := useCase.mysqlCollector.GetLockMetrics(context.Background())
-			if err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				metrics.MySQL.Locks = entity.LockMetrics{
-					RowLockWaits: lockMetrics.RowLockWaits,
-					RowLockTime:  lockMetrics.RowLockTime,
-					Deadlocks:    lockMetrics.Deadlocks,
-				}
-			}
+	// 收集MySQL Buffer Pool指标
+	bufferPoolMetrics, err := useCase.mysqlCollector.GetBufferPoolMetrics(ctx, name)
+	if err != nil {
+		metrics.Error = err
+	} else {
+		metrics.BufferPool = entity.BufferPoolMetrics{
+			HitRate:       bufferPoolMetrics.HitRate,
+			Usage:         bufferPoolMetrics.Usage,
+			PagesTotal:    bufferPoolMetrics.PagesTotal,
+			PagesData:     bufferPoolMetrics.PagesData,
+			PagesFree:     bufferPoolMetrics.PagesFree,
+			PagesDirty:    bufferPoolMetrics.PagesDirty,
+			ReadRequests:  bufferPoolMetrics.ReadRequests,
+			Reads:         bufferPoolMetrics.Reads,
+			WriteRequests: bufferPoolMetrics.WriteRequests,
+			Writes:        bufferPoolMetrics.Writes,
+		}
+	}
 
-			// 收集MySQL事务指标
-			transactionMetrics, err := useCase.mysqlCollector.GetTransactionMetrics(context.Background())
-			if err != nil {
-				metrics.MySQL.Error = err
-			} else {
-				metrics.MySQL.Transactions = entity.TransactionMetrics{
-					UncommittedTransactions: transactionMetrics.UncommittedTransactions,
-					BinlogGrowthRate:        transactionMetrics.BinlogGrowthRate,
-				}
-			}
+	// 收集MySQL锁指标
+	lockMetrics, err := useCase.mysqlCollector.GetLockMetrics(ctx, name)
+	if err != nil {
+		metrics.Error = err
+	} else {
+		metrics.Locks = entity.LockMetrics{
+			RowLockWaits: lockMetrics.RowLockWaits,
+			RowLockTime:  lockMetrics.RowLockTime,
+			Deadlocks:    lockMetrics.Deadlocks,
+			RowLockWaitsPerMinute: lockMetrics.RowLockWaitsPerMinute,
+			DeadlocksPerHour:      lockMetrics.DeadlocksPerHour,
+		}
+	}
 
-			// 收集MySQL复制指标（如果启用）
-			if config.AppMonitoring.MySQL.Replication != nil && config.AppMonitoring.MySQL.Replication.Enabled {
-				replicationMetrics, err := useCase.mysqlCollector.GetReplicationMetrics(context.Background())
-				if err != nil {
-					metrics.MySQL.Error = err
-				} else {
-					metrics.MySQL.Replication = &entity.ReplicationMetrics{
-						SlaveIORunning:        replicationMetrics.SlaveIORunning,
-						SlaveSQLRunning:       replicationMetrics.SlaveSQLRunning,
-						SecondsBehindMaster:   replicationMetrics.SecondsBehindMaster,
-						MasterLogFile:         replicationMetrics.MasterLogFile,
-						ReadMasterLogPos:      replicationMetrics.ReadMasterLogPos,
-						RelayLogFile:          replicationMetrics.RelayLogFile,
-						RelayLogPos:           replicationMetrics.RelayLogPos,
-						GTIDMode:              replicationMetrics.GTIDMode,
-						GTIDExecuted:          replicationMetrics.GTIDExecuted,
-					}
-				}
+	// 收集MySQL事务指标
+	transactionMetrics, err := useCase.mysqlCollector.GetTransactionMetrics(ctx, name)
+	if err != nil {
+		metrics.Error = err
+	} else {
+		metrics.Transactions = entity.TransactionMetrics{
+			UncommittedTransactions: transactionMetrics.UncommittedTransactions,
+			BinlogGrowthRate:        transactionMetrics.BinlogGrowthRate,
+		}
+	}
+
+	// 收集MySQL复制指标（仅从库）
+	if target.IsReplica() {
+		replicationMetrics, err := useCase.mysqlCollector.GetReplicationMetrics(ctx, name)
+		if err != nil {
+			metrics.Error = err
+		} else {
+			metrics.Replication = &entity.ReplicationMetrics{
+				SlaveIORunning:      replicationMetrics.SlaveIORunning,
+				SlaveSQLRunning:     replicationMetrics.SlaveSQLRunning,
+				SecondsBehindMaster: replicationMetrics.SecondsBehindMaster,
+				MasterLogFile:       replicationMetrics.MasterLogFile,
+				ReadMasterLogPos:    replicationMetrics.ReadMasterLogPos,
+				RelayLogFile:        replicationMetrics.RelayLogFile,
+				RelayLogPos:         replicationMetrics.RelayLogPos,
+				GTIDMode:            replicationMetrics.GTIDMode,
+				GTIDExecuted:        replicationMetrics.GTIDExecuted,
 			}
 		}
 	}
@@ -555,92 +465,166 @@ func (useCase *MonitoringUseCase) CollectBaseOnce(config *entity.Config) *entity
 	return metrics
 }
 
+// isConnInited 返回指定类型实例的连接是否已初始化
+func (useCase *MonitoringUseCase) isConnInited(kind, name string) bool {
+	useCase.connMu.Lock()
+	defer useCase.connMu.Unlock()
+	return useCase.connInited[kind+":"+name]
+}
+
+// setConnInited 记录指定类型实例的连接初始化状态
+func (useCase *MonitoringUseCase) setConnInited(kind, name string, inited bool) {
+	useCase.connMu.Lock()
+	defer useCase.connMu.Unlock()
+	if useCase.connInited == nil {
+		useCase.connInited = map[string]bool{}
+	}
+	useCase.connInited[kind+":"+name] = inited
+}
+
 // CollectHTTPOnce 仅采集 HTTP 接口指标
 func (useCase *MonitoringUseCase) CollectHTTPOnce(config *entity.Config) *entity.SystemMetrics {
 	metrics := &entity.SystemMetrics{Timestamp: time.Now()}
 
 	// HTTP接口监控：只有当app_monitoring存在且启用，HTTP配置存在且启用时才执行
 	if config != nil && config.AppMonitoring != nil && config.AppMonitoring.Enabled && config.AppMonitoring.HTTP != nil && config.AppMonitoring.HTTP.Enabled {
-		if !useCase.isHTTPInited {
+		if !useCase.isConnInited("http", "") {
 			if err := useCase.httpCollector.Init(); err != nil {
 				metrics.HTTP.Error = err
 				return metrics
 			}
-			useCase.isHTTPInited = true
-		}
-
-		if useCase.isHTTPInited {
-			var httpInterfaces []entity.HTTPInterfaceMetrics
-			if config.AppMonitoring.HTTP.Interfaces != nil {
-				count := len(config.AppMonitoring.HTTP.Interfaces)
-				results := make([]entity.HTTPInterfaceMetrics, count)
-				var wg sync.WaitGroup
-				wg.Add(count)
-				for i := 0; i < count; i++ {
-					i := i
-					httpConfig := config.AppMonitoring.HTTP.Interfaces[i]
-					go func() {
-						defer wg.Done()
-						isAccessible, responseTime, statusCode, err := useCase.httpCollector.CheckInterface(httpConfig.URL, httpConfig.Timeout)
-						results[i] = entity.HTTPInterfaceMetrics{
-							Name:         httpConfig.Name,
-							URL:          httpConfig.URL,
-							IsAccessible: isAccessible,
-							ResponseTime: responseTime,
-							StatusCode:   statusCode,
-							Error:        err,
-							NeedAlert:    httpConfig.NeedAlert,
-							AllowedCodes: httpConfig.AllowedCodes,
-						}
-					}()
-				}
-				wg.Wait()
-				httpInterfaces = results
+			useCase.setConnInited("http", "", true)
+		}
+
+		var httpInterfaces []entity.HTTPInterfaceMetrics
+		if config.AppMonitoring.HTTP.Interfaces != nil {
+			count := len(config.AppMonitoring.HTTP.Interfaces)
+			results := make([]entity.HTTPInterfaceMetrics, count)
+			var wg sync.WaitGroup
+			wg.Add(count)
+			for i := 0; i < count; i++ {
+				i := i
+				httpConfig := config.AppMonitoring.HTTP.Interfaces[i]
+				go func() {
+					defer wg.Done()
+					isAccessible, responseTime, statusCode, err := useCase.httpCollector.CheckInterface(httpConfig.URL, httpConfig.Timeout)
+					results[i] = entity.HTTPInterfaceMetrics{
+						Name:         httpConfig.Name,
+						URL:          httpConfig.URL,
+						IsAccessible: isAccessible,
+						ResponseTime: responseTime,
+						StatusCode:   statusCode,
+						Error:        err,
+						NeedAlert:    httpConfig.NeedAlert,
+						AllowedCodes: httpConfig.AllowedCodes,
+					}
+				}()
 			}
-			metrics.HTTP.Interfaces = httpInterfaces
+			wg.Wait()
+			httpInterfaces = results
 		}
+		metrics.HTTP.Interfaces = httpInterfaces
 	}
 
 	return metrics
 }
 
-// NotifyWithAlertTypes 按给定的告警类型集合直接构建并发送通知（用于“同时告警”合并场景）
-func (useCase *MonitoringUseCase) NotifyWithAlertTypes(config *entity.Config, metrics *entity.SystemMetrics, alertTypes []entity.AlertType) error {
-	if len(alertTypes) == 0 {
+// Snapshot 采集一次全部指标并做阈值判断，返回指标、命中的告警和 Markdown 报告
+// 不经过告警策略、不发送通知，也不触发 Java 堆转储（用于 once 命令）
+func (useCase *MonitoringUseCase) Snapshot(config *entity.Config) (*entity.SystemMetrics, []domainAlert.TriggeredAlert, string) {
+	metrics := useCase.CollectOnce(config)
+	decisions, err := useCase.evaluator.Evaluate(config, metrics)
+	if err != nil {
+		log.Printf("[WARN] 阈值判断失败: %v", err)
+	}
+
+	var alerts []domainAlert.TriggeredAlert
+	for _, d := range decisions {
+		message := d.Message
+		if message == "" {
+			message = d.Type.Describe(d.Target)
+		}
+		alerts = append(alerts, domainAlert.TriggeredAlert{Type: d.Type, Target: d.Target, Severity: d.Severity, Message: message})
+	}
+
+	title := "GWatch 监控快照"
+	if config.HostMonitoring != nil && config.HostMonitoring.AlertTitle != "" {
+		title = config.HostMonitoring.AlertTitle
+	}
+	return metrics, alerts, useCase.alertFormatter.Build(title, config, metrics, alerts)
+}
+
+// NotifyWithAlertTypes 按给定的告警（类型 + 对象）集合直接构建并发送通知（用于“同时告警”合并场景）
+func (useCase *MonitoringUseCase) NotifyWithAlertTypes(config *entity.Config, metrics *entity.SystemMetrics, decisions []domainMonitor.Decision) error {
+	return useCase.notifyWithAlertTypes(config, metrics, decisions, useCase.ownPolicy)
+}
+
+// notifyWithAlertTypes 同 NotifyWithAlertTypes，静默、白名单与发送结果记录到 policyOf 返回的告警策略（产生该告警的策略）
+func (useCase *MonitoringUseCase) notifyWithAlertTypes(config *entity.Config, metrics *entity.SystemMetrics, decisions []domainMonitor.Decision, policyOf policyOf) error {
+	if len(decisions) == 0 {
 		return nil
 	}
 
 	var triggeredAlerts []domainAlert.TriggeredAlert
+	var events []entity.AlertEvent
 	isDumpTriggeredAsync := false
+	now := time.Now()
+
+	for _, decision := range decisions {
+		// 静默期内的告警保留告警事件（记录静默 ID），不构建消息、不触发堆转储、不发送
+		if s := useCase.silencer.Match(config, decision.Type, decision.Target, now); s != nil {
+			log.Printf("[静默] %s 命中静默 %s（%s），已记录但不发送", decision.Type.Describe(decision.Target), s.ID, s.SilenceMatchers)
+			policyOf(decision).MarkSilenced(decision, s.ID)
+			events = append(events, alertEvent(entity.AlertEventSilenced, decision, "", s.ID))
+			continue
+		}
 
-	for _, alertType := range alertTypes {
-		message, isAsyncDump, isSkipped := useCase.buildAlertMessageAndMaybeDump(config, metrics, alertType)
+		// 抖动通知使用策略生成的消息，不附带指标、不触发堆转储
+		message, isAsyncDump, isSkipped := decision.Message, false, false
+		if !decision.Flapping {
+			message, isAsyncDump, isSkipped = useCase.buildAlertMessageAndMaybeDump(config, metrics, decision)
+		}
 
 		if isSkipped || strings.TrimSpace(message) == "" {
-			log.Printf("[完全跳过告警] 类型: %v, 原因: %s", alertType, map[bool]string{true: "白名单", false: "消息为空"}[isSkipped])
+			log.Printf("[完全跳过告警] 类型: %v, 原因: %s", decision.Type.Describe(decision.Target), map[bool]string{true: "白名单", false: "消息为空"}[isSkipped])
+			policyOf(decision).Discard(decision)
 			continue
 		}
 
 		if isAsyncDump {
 			isDumpTriggeredAsync = true
 		}
+		policyOf(decision).MarkSilenced(decision, "")
+		events = append(events, alertEvent(entity.AlertEventFired, decision, message, ""))
 
+		severity := decision.Severity
+		if severity == "" {
+			severity = entity.SeverityWarning
+		}
 		triggeredAlerts = append(triggeredAlerts, domainAlert.TriggeredAlert{
-			Type:    alertType,
-			Message: message,
+			Type:      decision.Type,
+			Target:    decision.Target,
+			Severity:  severity,
+			Message:   message,
+			Escalated: decision.Escalated,
+			Flapping:  decision.Flapping,
 		})
 	}
 
-	// ✅✅✅ 核心新增：如果所有告警项都被过滤 → 不发送通知
+	useCase.recordHistory(config, events)
+
+	// ✅✅✅ 核心新增：如果所有告警项都被过滤或静默 → 不发送通知
 	if len(triggeredAlerts) == 0 {
 		log.Println("[INFO] 所有告警项均被过滤，本次通知已取消")
 		return nil // 👈 彻底静默，不发任何消息
 	}
 
+	var notes []domainAlert.TriggeredAlert
 	if isDumpTriggeredAsync {
-		triggeredAlerts = append(triggeredAlerts, domainAlert.TriggeredAlert{
-			Type:    entity.Info,
-			Message: "检测到高负载，已自动触发 Java 堆转储生成（异步执行中）...",
+		notes = append(notes, domainAlert.TriggeredAlert{
+			Type:     entity.Info,
+			Severity: entity.SeverityInfo,
+			Message:  "检测到高负载，已自动触发 Java 堆转储生成（异步执行中）...",
 		})
 	}
 
@@ -648,8 +632,94 @@ func (useCase *MonitoringUseCase) NotifyWithAlertTypes(config *entity.Config, me
 	if config.HostMonitoring != nil && config.HostMonitoring.AlertTitle != "" {
 		alertTitle = config.HostMonitoring.AlertTitle
 	}
-	alertBody := useCase.alertFormatter.Build(alertTitle, config, metrics, triggeredAlerts)
-	return useCase.alertNotifier.Send(alertTitle, alertBody)
+	// 按路由拆分：每组渠道只收到命中的告警项，升级的告警额外发送到 escalation 的渠道
+	keys := make([]routeKey, len(triggeredAlerts))
+	for i, a := range triggeredAlerts {
+		keys[i] = routeKey{Type: a.Type, Target: a.Target, Severity: a.Severity, Escalated: a.Escalated}
+	}
+	if !needsRouting(config, keys) {
+		alertBody := useCase.alertFormatter.Build(alertTitle, config, metrics, append(triggeredAlerts, notes...))
+		return useCase.alertNotifier.Send(alertTitle, alertBody)
+	}
+	var errs []error
+	for _, r := range routeAlerts(config, keys) {
+		alerts := make([]domainAlert.TriggeredAlert, 0, len(r.indexes)+len(notes))
+		for _, i := range r.indexes {
+			alerts = append(alerts, triggeredAlerts[i])
+		}
+		alertBody := useCase.alertFormatter.Build(alertTitle, config, metrics, append(alerts, notes...))
+		if err := useCase.alertNotifier.SendTo(r.channels, r.atMobiles, alertTitle, alertBody); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyRecoveries 发送告警恢复通知（附带持续时长与峰值），没有恢复事件时不发送
+func (useCase *MonitoringUseCase) NotifyRecoveries(config *entity.Config, metrics *entity.SystemMetrics, recoveries []domainMonitor.Recovery) error {
+	// 告警历史记录全部恢复事件，包括不发送恢复通知的静默告警
+	events := make([]entity.AlertEvent, 0, len(recoveries))
+	for _, r := range recoveries {
+		events = append(events, recoveryEvent(r))
+	}
+	useCase.recordHistory(config, events)
+
+	recoveries = useCase.unsilencedRecoveries(config, recoveries)
+	if len(recoveries) == 0 {
+		return nil
+	}
+	title := "GWatch 服务器告警" // 默认标题
+	if config.HostMonitoring != nil && config.HostMonitoring.AlertTitle != "" {
+		title = config.HostMonitoring.AlertTitle
+	}
+	title = "【恢复】" + title
+
+	// 恢复通知与对应告警走同一路由，已升级的告警同样通知升级对象
+	keys := make([]routeKey, len(recoveries))
+	for i, r := range recoveries {
+		keys[i] = routeKey{Type: r.Type, Target: r.Target, Severity: r.Record.Severity, Escalated: r.Record.EscalatedAt != nil}
+	}
+	if !needsRouting(config, keys) {
+		body := useCase.alertFormatter.BuildRecovery(title, config, metrics, recoveries)
+		if err := useCase.alertNotifier.Send(title, body); err != nil {
+			log.Printf("[ERROR] 发送告警恢复通知失败: %v", err)
+			return err
+		}
+		return nil
+	}
+	var errs []error
+	for _, r := range routeAlerts(config, keys) {
+		picked := make([]domainMonitor.Recovery, 0, len(r.indexes))
+		for _, i := range r.indexes {
+			picked = append(picked, recoveries[i])
+		}
+		body := useCase.alertFormatter.BuildRecovery(title, config, metrics, picked)
+		if err := useCase.alertNotifier.SendTo(r.channels, r.atMobiles, title, body); err != nil {
+			log.Printf("[ERROR] 发送告警恢复通知失败: %v", err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unsilencedRecoveries 过滤掉不需要通知的恢复事件：最近一次告警被静默（从未通知或通知已被静默），或恢复时仍处于静默期
+func (useCase *MonitoringUseCase) unsilencedRecoveries(config *entity.Config, recoveries []domainMonitor.Recovery) []domainMonitor.Recovery {
+	now := time.Now()
+	result := recoveries[:0:0]
+	for _, r := range recoveries {
+		silenceID := r.Record.SilencedBy
+		if silenceID == "" {
+			if s := useCase.silencer.Match(config, r.Type, r.Target, now); s != nil {
+				silenceID = s.ID
+			}
+		}
+		if silenceID != "" {
+			log.Printf("[静默] %s 已恢复，命中静默 %s，不发送恢复通知", r.Type.Describe(r.Target), silenceID)
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
 }
 
 // buildAlertMessageAndMaybeDump 根据告警类型构建消息，并根据需要执行dump脚本
@@ -657,11 +727,51 @@ func (useCase *MonitoringUseCase) NotifyWithAlertTypes(config *entity.Config, me
 func (useCase *MonitoringUseCase) buildAlertMessageAndMaybeDump(
 	config *entity.Config,
 	metrics *entity.SystemMetrics,
-	alertType entity.AlertType,
+	decision domainMonitor.Decision,
 ) (string, bool, bool) {
-	message := alertType.String() // 默认使用 AlertType 的 String()
+	alertType := decision.Type
+	message := alertType.Describe(decision.Target) // 默认使用带对象名称的告警中文名
+	if decision.Message != "" {
+		message = decision.Message // 自定义规则已渲染好消息
+	}
 	isTriggerAsyncDump := false
 
+	// 挂载点告警附带当前使用率与阈值
+	if (alertType == entity.DiskHigh || alertType == entity.DiskInodeHigh) && decision.Target != "" && config.HostMonitoring != nil {
+		for _, mount := range metrics.Disk.Mounts {
+			if mount.Mount != decision.Target {
+				continue
+			}
+			if alertType == entity.DiskHigh {
+				message = fmt.Sprintf("%s: %.2f%% (%d/%d GB)，阈值 %.0f%%", message, mount.Percent, mount.UsedGB, mount.TotalGB, config.HostMonitoring.MountThreshold(mount.Mount))
+			} else if config.HostMonitoring.Disks != nil {
+				message = fmt.Sprintf("%s: %.2f%% (%d/%d)，阈值 %.0f%%", message, mount.InodesPercent, mount.InodesUsed, mount.InodesTotal, config.HostMonitoring.Disks.InodeThreshold)
+			}
+		}
+	}
+
+	// 网卡告警附带当前速率与阈值
+	if (alertType == entity.NetBandwidthHigh || alertType == entity.NetErrorsHigh || alertType == entity.NetDropsHigh) &&
+		config.HostMonitoring != nil && config.HostMonitoring.Network != nil {
+		n := config.HostMonitoring.Network
+		for _, itf := range metrics.Network.Interfaces {
+			if itf.Name != decision.Target {
+				continue
+			}
+			switch alertType {
+			case entity.NetBandwidthHigh:
+				message = fmt.Sprintf("%s: %.2f%% (下载 %.2f KB/s | 上传 %.2f KB/s，链路 %d Mbps)，阈值 %.0f%%",
+					message, itf.BandwidthUsed, itf.RecvKBps, itf.SentKBps, itf.SpeedMbps, n.BandwidthThreshold)
+			case entity.NetErrorsHigh:
+				message = fmt.Sprintf("%s: %.2f 个/秒 (收 %.2f | 发 %.2f)，阈值 %.2f 个/秒",
+					message, itf.ErrorRate(), itf.ErrInPs, itf.ErrOutPs, n.ErrorRateThreshold)
+			case entity.NetDropsHigh:
+				message = fmt.Sprintf("%s: %.2f 个/秒 (收 %.2f | 发 %.2f)，阈值 %.2f 个/秒",
+					message, itf.DropRate(), itf.DropInPs, itf.DropOutPs, n.DropRateThreshold)
+			}
+		}
+	}
+
 	if alertType == entity.CPUHigh || alertType == entity.MemHigh {
 		if topCPUProcesses, topMemProcesses, err := useCase.hostCollector.GetTopProcesses(5); err == nil {
 			var culpritProcess *entity.ProcessInfo
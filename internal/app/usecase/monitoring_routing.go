@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// routeKey 告警路由的匹配依据
+type routeKey struct {
+	Type      entity.AlertType
+	Target    string
+	Severity  string
+	Escalated bool // 升级通知，额外发送到 escalation 的渠道与 @ 列表
+}
+
+// routedGroup 发送到同一组渠道、使用同一 @ 列表的告警
+type routedGroup struct {
+	channels  []string
+	atMobiles []string
+	indexes   []int // 命中的告警在入参中的下标
+}
+
+// needsRouting 是否需要按渠道拆分发送：配置了 routing，或有告警需要升级到 escalation 的渠道与 @ 列表
+func needsRouting(cfg *entity.Config, keys []routeKey) bool {
+	if cfg.Routing != nil {
+		return true
+	}
+	if cfg.Escalation != nil {
+		for _, k := range keys {
+			if k.Escalated {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// routeAlerts 按 routing 配置为每个告警选择渠道与 @ 列表（未配置 routing 时为全部渠道），升级的告警再追加 escalation 的渠道与 @ 列表，
+// 最后把收到完全相同告警集合与 @ 列表的渠道合并为一组，每组只需构建、发送一次消息
+func routeAlerts(cfg *entity.Config, keys []routeKey) []routedGroup {
+	routing := cfg.Routing
+	if routing == nil {
+		routing = &entity.RoutingConfig{}
+	}
+	var allChannels []string
+	for _, ch := range cfg.NotificationChannels() {
+		allChannels = append(allChannels, ch.Name)
+	}
+	defaultChannels := routing.Default.Channels
+	if len(defaultChannels) == 0 {
+		defaultChannels = allChannels
+	}
+	hosts := utils.HostIdentities()
+
+	// 渠道 -> 命中的告警下标与额外 @ 列表
+	type channelRoute struct {
+		indexes   []int
+		atMobiles []string
+	}
+	perChannel := map[string]*channelRoute{}
+	var channelOrder []string
+	deliver := func(channels, atMobiles []string, idx int) {
+		for _, name := range channels {
+			cr, ok := perChannel[name]
+			if !ok {
+				cr = &channelRoute{}
+				perChannel[name] = cr
+				channelOrder = append(channelOrder, name)
+			}
+			if n := len(cr.indexes); n == 0 || cr.indexes[n-1] != idx {
+				cr.indexes = append(cr.indexes, idx)
+			}
+			cr.atMobiles = appendUnique(cr.atMobiles, atMobiles...)
+		}
+	}
+
+	for i, k := range keys {
+		matched := false
+		for _, r := range routing.Routes {
+			if !routeMatches(&r, k, hosts) {
+				continue
+			}
+			matched = true
+			channels := r.Channels
+			if len(channels) == 0 {
+				channels = defaultChannels
+			}
+			deliver(channels, r.AtMobiles, i)
+			if !r.Continue {
+				break
+			}
+		}
+		if !matched {
+			deliver(defaultChannels, routing.Default.AtMobiles, i)
+		}
+		// 升级：escalation 的 @ 列表发送到该告警已命中的渠道，并追加 escalation 的渠道
+		if esc := cfg.Escalation; k.Escalated && esc != nil {
+			var routed []string
+			for _, name := range channelOrder {
+				if cr := perChannel[name]; len(cr.indexes) > 0 && cr.indexes[len(cr.indexes)-1] == i {
+					routed = append(routed, name)
+				}
+			}
+			deliver(appendUnique(routed, esc.Channels...), esc.AtMobiles, i)
+		}
+	}
+
+	var groups []routedGroup
+	groupIndex := map[string]int{}
+	for _, name := range channelOrder {
+		cr := perChannel[name]
+		sort.Strings(cr.atMobiles)
+		key := fmt.Sprint(cr.indexes) + "|" + strings.Join(cr.atMobiles, ",")
+		if gi, ok := groupIndex[key]; ok {
+			groups[gi].channels = append(groups[gi].channels, name)
+			continue
+		}
+		groupIndex[key] = len(groups)
+		groups = append(groups, routedGroup{channels: []string{name}, atMobiles: cr.atMobiles, indexes: cr.indexes})
+	}
+	return groups
+}
+
+// routeMatches 判断告警是否满足路由的全部匹配条件，条件为空表示不限
+func routeMatches(r *entity.AlertRoute, k routeKey, hosts []string) bool {
+	if len(r.AlertTypes) > 0 && !utils.MatchAny(r.AlertTypes, string(k.Type)) {
+		return false
+	}
+	if len(r.Severities) > 0 && !utils.MatchAny(r.Severities, k.Severity) {
+		return false
+	}
+	if len(r.Targets) > 0 && !utils.MatchAny(r.Targets, k.Target) {
+		return false
+	}
+	if len(r.Hosts) > 0 {
+		for _, h := range hosts {
+			if utils.MatchAny(r.Hosts, h) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// appendUnique 追加不重复的元素
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		exists := false
+		for _, v := range list {
+			if v == item {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -27,7 +27,7 @@ func NewSystemMetricsService(
 }
 
 // CollectBasicMetrics 收集基础系统指标
-func (sms *SystemMetricsService) CollectBasicMetrics() *entity.SystemMetrics {
+func (sms *SystemMetricsService) CollectBasicMetrics(config *entity.Config) *entity.SystemMetrics {
 	m := &entity.SystemMetrics{Timestamp: time.Now()}
 
 	// 收集基础指标
@@ -37,9 +37,11 @@ func (sms *SystemMetricsService) CollectBasicMetrics() *entity.SystemMetrics {
 	m.Disk.ReadKBps, m.Disk.WriteKBps, _ = sms.hostCollector.GetDiskIORate()
 	m.Network.DownloadKBps, m.Network.UploadKBps, m.Network.Error = sms.hostCollector.GetNetworkRate()
 
-	// 收集Redis信息
-	if clientCount, err := sms.redisClient.GetClients(); err == nil {
-		m.Redis.ClientCount = clientCount
+	// 收集Redis信息（复用监控循环已建立的连接）
+	for _, target := range config.AppMonitoring.RedisTargets() {
+		r := entity.RedisMetrics{Name: target.Name, Addr: target.Addr}
+		r.ClientCount, r.ConnectionError = sms.redisClient.GetClients(target.Name)
+		m.Redis = append(m.Redis, r)
 	}
 
 	return m
@@ -47,7 +49,7 @@ func (sms *SystemMetricsService) CollectBasicMetrics() *entity.SystemMetrics {
 
 // CollectFullMetrics 收集完整的系统指标（包括HTTP接口）
 func (sms *SystemMetricsService) CollectFullMetrics(config *entity.Config) *entity.SystemMetrics {
-    m := sms.CollectBasicMetrics()
+    m := sms.CollectBasicMetrics(config)
 
     // 收集HTTP接口信息（仅当启用并配置了HTTP）
     if config.AppMonitoring != nil && config.AppMonitoring.HTTP != nil && len(config.AppMonitoring.HTTP.Interfaces) > 0 {
@@ -4,6 +4,8 @@ import (
 	domainMonitor "GWatch/internal/domain/monitoring"
 	"GWatch/internal/entity"
 	policyImpl "GWatch/internal/infra/monitoring"
+	"log"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -18,27 +20,54 @@ type Coordinator struct {
 	mu         sync.RWMutex
 	latestBase *entity.SystemMetrics
 	latestHTTP *entity.SystemMetrics
+
+	// 热加载：当前生效配置与两个调度循环的重载信号
+	cfgMu      sync.RWMutex
+	cfg        *entity.Config
+	baseReload chan bool
+	httpReload chan bool
 }
 
 func NewCoordinator(runnerBase, runnerHTTP *MonitoringUseCase, policyBase, policyHTTP *policyImpl.StatefulPolicy) *Coordinator {
-	return &Coordinator{runnerBase: runnerBase, runnerHTTP: runnerHTTP, policyBase: policyBase, policyHTTP: policyHTTP}
+	return &Coordinator{
+		runnerBase: runnerBase,
+		runnerHTTP: runnerHTTP,
+		policyBase: policyBase,
+		policyHTTP: policyHTTP,
+		baseReload: make(chan bool, 1),
+		httpReload: make(chan bool, 1),
+	}
+}
+
+// UpdateConfig 热加载新配置：调度循环在下一次触发时使用新配置，
+// 间隔变化时重置 ticker，应用监控连接配置变化时重新初始化采集器；告警策略状态保持不变
+func (c *Coordinator) UpdateConfig(cfg *entity.Config) {
+	c.cfgMu.Lock()
+	old := c.cfg
+	c.cfg = cfg
+	c.cfgMu.Unlock()
+
+	resetCollectors := old == nil || !reflect.DeepEqual(old.AppMonitoring, cfg.AppMonitoring)
+	notifyReload(c.baseReload, resetCollectors)
+	notifyReload(c.httpReload, resetCollectors)
+}
+
+// currentConfig 返回当前生效的配置
+func (c *Coordinator) currentConfig() *entity.Config {
+	c.cfgMu.RLock()
+	defer c.cfgMu.RUnlock()
+	return c.cfg
 }
 
 // RunWithIntervals 启动双周期调度，stopCh 关闭时退出
 func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{}) {
-	// 获取监控间隔配置
-	var baseInterval, httpInterval time.Duration
-	if cfg.HostMonitoring != nil && cfg.HostMonitoring.Enabled {
-		baseInterval = cfg.HostMonitoring.Interval
-	} else {
-		baseInterval = 5 * time.Second // 默认间隔
-	}
-	
-	if cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled && cfg.AppMonitoring.HTTP != nil && cfg.AppMonitoring.HTTP.Enabled {
-		httpInterval = cfg.AppMonitoring.HTTP.Interval
-	} else {
-		httpInterval = 10 * time.Second // 默认间隔
+	c.cfgMu.Lock()
+	// 启动前已收到热加载的配置时以其为准，避免被启动时读取的旧配置覆盖
+	if c.cfg == nil {
+		c.cfg = cfg
 	}
+	cfg = c.cfg
+	c.cfgMu.Unlock()
 
 	// 首次采集
 	c.latestBase = c.runnerBase.CollectBaseOnce(cfg)
@@ -50,11 +79,22 @@ func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{
 	// 基础周期 goroutine
 	go func() {
 		defer wg.Done()
-		ticker := time.NewTicker(baseInterval)
+		interval := cfg.BaseInterval()
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 		for {
 			select {
+			case resetCollectors := <-c.baseReload:
+				if resetCollectors {
+					c.runnerBase.ResetConnections()
+				}
+				if next := c.currentConfig().BaseInterval(); next != interval {
+					log.Printf("基础监控间隔已更新: %v -> %v", interval, next)
+					interval = next
+					ticker.Reset(interval)
+				}
 			case <-ticker.C:
+				cfg := c.currentConfig()
 				base := c.runnerBase.CollectBaseOnce(cfg)
 				c.mu.Lock()
 				c.latestBase = base
@@ -69,9 +109,10 @@ func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{
 					c.mu.Unlock()
 
 					decisions := c.evaluate(cfg, merged)
-					baseTypes := c.policyBase.Apply(cfg, merged, filterNonHTTP(decisions))
-					httpTypes := c.policyHTTP.PeekApply(cfg, merged, filterOnlyHTTP(decisions))
-					_ = c.runnerBase.NotifyWithAlertTypes(cfg, merged, unionTypes(baseTypes, httpTypes))
+					baseAlerts := c.policyBase.Apply(cfg, merged, filterNonHTTP(decisions))
+					httpAlerts := c.policyHTTP.ApplyMerged(cfg, merged, filterOnlyHTTP(decisions))
+					_ = c.runnerBase.NotifyRecoveries(cfg, merged, c.policyBase.Resolve(cfg, filterNonHTTP(decisions)))
+					_ = c.runnerBase.notifyWithAlertTypes(cfg, merged, c.runnerBase.inhibitAlerts(cfg, decisions, unionDecisions(baseAlerts, httpAlerts), c.policyOf), c.policyOf)
 					continue
 				}
 				c.runnerBase.PrintMetrics(cfg, merged)
@@ -85,11 +126,22 @@ func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{
 	// HTTP 周期 goroutine
 	go func() {
 		defer wg.Done()
-		ticker := time.NewTicker(httpInterval)
+		interval := cfg.HTTPInterval()
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 		for {
 			select {
+			case resetCollectors := <-c.httpReload:
+				if resetCollectors {
+					c.runnerHTTP.ResetConnections()
+				}
+				if next := c.currentConfig().HTTPInterval(); next != interval {
+					log.Printf("HTTP监控间隔已更新: %v -> %v", interval, next)
+					interval = next
+					ticker.Reset(interval)
+				}
 			case <-ticker.C:
+				cfg := c.currentConfig()
 				httpSnap := c.runnerHTTP.CollectHTTPOnce(cfg)
 				c.mu.Lock()
 				c.latestHTTP = httpSnap
@@ -104,9 +156,10 @@ func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{
 					c.mu.Unlock()
 
 					decisions := c.evaluate(cfg, merged)
-					httpTypes := c.policyHTTP.Apply(cfg, merged, filterOnlyHTTP(decisions))
-					baseTypes := c.policyBase.PeekApply(cfg, merged, filterNonHTTP(decisions))
-					_ = c.runnerHTTP.NotifyWithAlertTypes(cfg, merged, unionTypes(baseTypes, httpTypes))
+					httpAlerts := c.policyHTTP.Apply(cfg, merged, filterOnlyHTTP(decisions))
+					baseAlerts := c.policyBase.ApplyMerged(cfg, merged, filterNonHTTP(decisions))
+					_ = c.runnerHTTP.NotifyRecoveries(cfg, merged, c.policyHTTP.Resolve(cfg, filterOnlyHTTP(decisions)))
+					_ = c.runnerHTTP.notifyWithAlertTypes(cfg, merged, c.runnerHTTP.inhibitAlerts(cfg, decisions, unionDecisions(baseAlerts, httpAlerts), c.policyOf), c.policyOf)
 					continue
 				}
 				c.runnerHTTP.PrintMetrics(cfg, merged)
@@ -123,21 +176,39 @@ func (c *Coordinator) RunWithIntervals(cfg *entity.Config, stopCh <-chan struct{
 	wg.Wait()
 }
 
+// notifyReload 非阻塞地投递重载信号，未被消费的信号会与新信号合并
+func notifyReload(ch chan bool, resetCollectors bool) {
+	select {
+	case pending := <-ch:
+		resetCollectors = resetCollectors || pending
+	default:
+	}
+	select {
+	case ch <- resetCollectors:
+	default:
+	}
+}
+
 func (c *Coordinator) evaluate(cfg *entity.Config, m *entity.SystemMetrics) []domainMonitor.Decision {
 	dec, _ := c.runnerBase.evaluator.Evaluate(cfg, m)
 	return dec
 }
 
+// wouldTrigger 按本周期告警策略的实际状态（连续计数、防抖、抖动）预判是否会触发告警，不修改策略状态
 func (c *Coordinator) wouldTrigger(cfg *entity.Config, m *entity.SystemMetrics, base bool) bool {
 	decisions := c.evaluate(cfg, m)
 	if base {
-		peek := policyImpl.NewStatefulPolicy()
-		alerts := peek.Apply(cfg, m, filterNonHTTP(decisions))
-		return len(alerts) > 0
+		return len(c.policyBase.PeekApply(cfg, m, filterNonHTTP(decisions))) > 0
 	}
-	peek := policyImpl.NewStatefulPolicy()
-	alerts := peek.Apply(cfg, m, filterOnlyHTTP(decisions))
-	return len(alerts) > 0
+	return len(c.policyHTTP.PeekApply(cfg, m, filterOnlyHTTP(decisions))) > 0
+}
+
+// policyOf 合并通知中告警所属的告警策略：HTTP 告警属于 HTTP 周期的策略，其余属于基础周期的策略（与 filterOnlyHTTP/filterNonHTTP 一致）
+func (c *Coordinator) policyOf(d domainMonitor.Decision) domainMonitor.Policy {
+	if d.Type == entity.HTTPErr {
+		return c.policyHTTP
+	}
+	return c.policyBase
 }
 
 func filterOnlyHTTP(decisions []domainMonitor.Decision) []domainMonitor.Decision {
@@ -160,17 +231,21 @@ func filterNonHTTP(decisions []domainMonitor.Decision) []domainMonitor.Decision
 	return res
 }
 
-func unionTypes(a, b []entity.AlertType) []entity.AlertType {
-	set := map[entity.AlertType]struct{}{}
-	for _, t := range a {
-		set[t] = struct{}{}
+// unionDecisions 按（类型, 对象）去重合并两组告警，保持先后顺序
+func unionDecisions(a, b []domainMonitor.Decision) []domainMonitor.Decision {
+	type key struct {
+		t      entity.AlertType
+		target string
 	}
-	for _, t := range b {
-		set[t] = struct{}{}
-	}
-	res := make([]entity.AlertType, 0, len(set))
-	for t := range set {
-		res = append(res, t)
+	set := map[key]struct{}{}
+	res := make([]domainMonitor.Decision, 0, len(a)+len(b))
+	for _, d := range append(append([]domainMonitor.Decision{}, a...), b...) {
+		k := key{d.Type, d.Target}
+		if _, ok := set[k]; ok {
+			continue
+		}
+		set[k] = struct{}{}
+		res = append(res, d)
 	}
 	return res
 }
@@ -11,9 +11,13 @@ import (
 	"log"
 	"net"
 	"os"
+	"sync"
 	"time"
 )
 
+// diskForecastReportHorizon 定时报告列出预计在该时长内写满的挂载点
+const diskForecastReportHorizon = 7 * 24 * time.Hour
+
 // ScheduledPushUseCaseImpl 全局定时推送用例实现
 type ScheduledPushUseCaseImpl struct {
 	hostCollector         collector.HostCollector
@@ -28,6 +32,10 @@ type ScheduledPushUseCaseImpl struct {
 	alertStorage          scheduled_push.ScheduledPushAlertStorage
 	clientDataRepository  scheduled_push.ClientDataRepository
 	scheduledPushFormatter scheduled_push.ScheduledPushFormatter
+	silencer              monitoring.Silencer
+	flapHistory           monitoring.FlapHistory
+	diskForecaster        monitoring.DiskForecaster
+	lastReport            time.Time // 上次定时推送的时间，作为报告中抖动告警的统计起点
 }
 
 // NewScheduledPushUseCase 创建全局定时推送用例
@@ -44,6 +52,9 @@ func NewScheduledPushUseCase(
 	alertStorage scheduled_push.ScheduledPushAlertStorage,
 	clientDataRepository scheduled_push.ClientDataRepository,
 	scheduledPushFormatter scheduled_push.ScheduledPushFormatter,
+	silencer monitoring.Silencer,
+	flapHistory monitoring.FlapHistory,
+	diskForecaster monitoring.DiskForecaster,
 ) scheduled_push.ScheduledPushUseCase {
 	return &ScheduledPushUseCaseImpl{
 		hostCollector:          hostCollector,
@@ -58,6 +69,9 @@ func NewScheduledPushUseCase(
 		alertStorage:           alertStorage,
 		clientDataRepository:   clientDataRepository,
 		scheduledPushFormatter: scheduledPushFormatter,
+		silencer:               silencer,
+		flapHistory:            flapHistory,
+		diskForecaster:         diskForecaster,
 	}
 }
 
@@ -92,7 +106,7 @@ func (spu *ScheduledPushUseCaseImpl) getScheduledPushTitle(config *entity.Config
 }
 
 // collectBasicHostMetrics 收集基本主机指标（不依赖外部服务）
-func (spu *ScheduledPushUseCaseImpl) collectBasicHostMetrics() *entity.SystemMetrics {
+func (spu *ScheduledPushUseCaseImpl) collectBasicHostMetrics(config *entity.Config) *entity.SystemMetrics {
 	// 只收集CPU、内存、磁盘、网络等基本指标
 	hostMetrics := &entity.SystemMetrics{
 		Timestamp: time.Now(),
@@ -123,6 +137,13 @@ func (spu *ScheduledPushUseCaseImpl) collectBasicHostMetrics() *entity.SystemMet
 		Error:   err,
 	}
 
+	// 收集挂载点指标
+	var mountCfg *entity.DiskMountConfig
+	if config.HostMonitoring != nil {
+		mountCfg = config.HostMonitoring.Disks
+	}
+	hostMetrics.Disk.Mounts, hostMetrics.Disk.MountsError = spu.hostCollector.GetMountUsages(mountCfg)
+
 	// 收集磁盘IO指标
 	readKBps, writeKBps, err := spu.hostCollector.GetDiskIORate()
 	if err == nil {
@@ -137,51 +158,67 @@ func (spu *ScheduledPushUseCaseImpl) collectBasicHostMetrics() *entity.SystemMet
 		UploadKBps:   uploadKBps,
 		Error:        err,
 	}
+	var netCfg *entity.NetworkInterfaceConfig
+	if config.HostMonitoring != nil {
+		netCfg = config.HostMonitoring.Network
+	}
+	hostMetrics.Network.Interfaces, hostMetrics.Network.InterfacesError = spu.hostCollector.GetInterfaceRates(netCfg)
 
 	return hostMetrics
 }
 
-// collectRedisMetrics 收集Redis指标
-func (spu *ScheduledPushUseCaseImpl) collectRedisMetrics(config *entity.Config) *entity.RedisMetrics {
-	redisMetrics := &entity.RedisMetrics{
-		ClientCount: 0,
-	}
-
-	// 初始化Redis连接
-	if err := spu.redisClient.Init(); err != nil {
-		redisMetrics.ConnectionError = err
-		return redisMetrics
-	}
+// collectRedisMetrics 收集所有已启用Redis实例的指标
+func (spu *ScheduledPushUseCaseImpl) collectRedisMetrics(config *entity.Config) []entity.RedisMetrics {
+	var result []entity.RedisMetrics
+	for _, target := range config.AppMonitoring.RedisTargets() {
+		redisMetrics := entity.RedisMetrics{
+			Name:        target.Name,
+			Addr:        target.Addr,
+			ClientCount: 0,
+		}
 
-	// 获取Redis连接数
-	clientCount, err := spu.redisClient.GetClients()
-	if err != nil {
-		redisMetrics.ConnectionError = err
-	} else {
-		redisMetrics.ClientCount = clientCount
-	}
+		// 初始化Redis连接
+		if err := spu.redisClient.Init(target.Name); err != nil {
+			redisMetrics.ConnectionError = err
+			result = append(result, redisMetrics)
+			continue
+		}
 
-	// 获取Redis连接详情
-	clientDetails, err := spu.redisClient.GetClientsDetail()
-	if err != nil {
-		redisMetrics.DetailError = err
-	} else {
-		redisMetrics.ClientDetails = clientDetails
-	}
+		// 获取Redis连接数
+		clientCount, err := spu.redisClient.GetClients(target.Name)
+		if err != nil {
+			redisMetrics.ConnectionError = err
+		} else {
+			redisMetrics.ClientCount = clientCount
+		}
 
-	return redisMetrics
-}
+		// 获取Redis连接详情
+		clientDetails, err := spu.redisClient.GetClientsDetail(target.Name)
+		if err != nil {
+			redisMetrics.DetailError = err
+		} else {
+			redisMetrics.ClientDetails = clientDetails
+		}
 
-// collectMySQLMetrics 收集MySQL指标
-func (spu *ScheduledPushUseCaseImpl) collectMySQLMetrics(config *entity.Config) *entity.MySQLMetrics {
-	mysqlMetrics := &entity.MySQLMetrics{
-		Error: nil,
+		result = append(result, redisMetrics)
 	}
+	return result
+}
 
-	// 这里可以添加MySQL连接和指标收集逻辑
-	// 简化处理，只设置基本状态
-
-	return mysqlMetrics
+// collectMySQLMetrics 收集MySQL指标（每个已启用的实例一项）
+func (spu *ScheduledPushUseCaseImpl) collectMySQLMetrics(config *entity.Config) []entity.MySQLMetrics {
+	var result []entity.MySQLMetrics
+	for _, target := range config.AppMonitoring.MySQLTargets() {
+		// 这里可以添加MySQL连接和指标收集逻辑
+		// 简化处理，只设置基本状态
+		result = append(result, entity.MySQLMetrics{
+			Name:  target.Name,
+			Addr:  fmt.Sprintf("%s:%d", target.Host, target.Port),
+			Role:  target.Role,
+			Error: nil,
+		})
+	}
+	return result
 }
 
 // collectHTTPMetrics 收集HTTP指标
@@ -223,6 +260,7 @@ func (spu *ScheduledPushUseCaseImpl) collectHTTPMetrics(config *entity.Config) *
 // ScheduledPushSchedulerImpl 全局定时推送调度器实现
 type ScheduledPushSchedulerImpl struct {
 	scheduledPushUseCase scheduled_push.ScheduledPushUseCase
+	configMu             sync.RWMutex
 	config               *entity.Config
 	ticker               *time.Ticker
 	stopCh               chan struct{}
@@ -240,7 +278,7 @@ func NewScheduledPushScheduler(scheduledPushUseCase scheduled_push.ScheduledPush
 
 // Start 启动全局定时推送调度
 func (sps *ScheduledPushSchedulerImpl) Start(config *entity.Config, stopCh <-chan struct{}) error {
-	sps.config = config
+	sps.UpdateConfig(config)
 
 	// 每10秒检查一次是否到了推送时间，提高响应速度
 	sps.ticker = time.NewTicker(10 * time.Second)
@@ -250,13 +288,13 @@ func (sps *ScheduledPushSchedulerImpl) Start(config *entity.Config, stopCh <-cha
 
 		// 启动时立即检查一次，避免错过推送时间
 		log.Println("启动时检查全局定时推送时间...")
-		sps.executeScheduledPushIfNeeded(config, "启动时匹配到推送时间，立即执行全局监控报告")
+		sps.executeScheduledPushIfNeeded(sps.currentConfig(), "启动时匹配到推送时间，立即执行全局监控报告")
 
 		for {
 			select {
 			case <-sps.ticker.C:
-				// 检查是否到了推送时间
-				sps.executeScheduledPushIfNeeded(config, "定时器触发：开始执行全局监控报告")
+				// 检查是否到了推送时间（每次读取最新配置，支持热加载）
+				sps.executeScheduledPushIfNeeded(sps.currentConfig(), "定时器触发：开始执行全局监控报告")
 			case <-stopCh:
 				log.Println("全局定时推送调度器收到停止信号")
 				return
@@ -270,6 +308,20 @@ func (sps *ScheduledPushSchedulerImpl) Start(config *entity.Config, stopCh <-cha
 	return nil
 }
 
+// UpdateConfig 更新调度器使用的配置（配置热加载时调用），下一次检查即生效
+func (sps *ScheduledPushSchedulerImpl) UpdateConfig(config *entity.Config) {
+	sps.configMu.Lock()
+	defer sps.configMu.Unlock()
+	sps.config = config
+}
+
+// currentConfig 返回当前生效的配置
+func (sps *ScheduledPushSchedulerImpl) currentConfig() *entity.Config {
+	sps.configMu.RLock()
+	defer sps.configMu.RUnlock()
+	return sps.config
+}
+
 // executeScheduledPushIfNeeded 如果需要则执行全局定时推送
 func (sps *ScheduledPushSchedulerImpl) executeScheduledPushIfNeeded(config *entity.Config, logPrefix string) {
 	if config.ScheduledPush == nil || !config.ScheduledPush.Enabled {
@@ -363,7 +415,7 @@ func (spu *ScheduledPushUseCaseImpl) RunClientMode(config *entity.Config) error
 	}
 
 	// 收集主机监控指标（Client模式目前只收集主机监控数据）
-	hostMetrics := spu.collectBasicHostMetrics()
+	hostMetrics := spu.collectBasicHostMetrics(config)
 
 	// 构建客户端数据（目前只包含主机监控）
 	clientMetrics := &entity.ClientMetrics{
@@ -388,6 +440,10 @@ func (spu *ScheduledPushUseCaseImpl) RunClientMode(config *entity.Config) error
 		Title:     clientTitle,
 		Timestamp: time.Now(),
 		Metrics:   clientMetrics,
+		Silences:  spu.silencer.Active(config, time.Now()),
+		Flapping:  spu.flappedSinceLastReport(),
+
+		DiskForecasts: spu.diskForecasts(),
 	}
 
 	// 保存到 Redis，设置 5 分钟过期时间
@@ -454,7 +510,7 @@ func (spu *ScheduledPushUseCaseImpl) RunServerMode(config *entity.Config) error
 	log.Printf("[Server模式] Server自身IP: %s, HostName: %s", serverIP, serverHostName)
 
 	// 收集Server的主机监控数据
-	serverHostMetrics := spu.collectBasicHostMetrics()
+	serverHostMetrics := spu.collectBasicHostMetrics(config)
 
 	// 构建Server的监控数据
 	serverMetrics := &entity.ClientMetrics{
@@ -469,18 +525,18 @@ func (spu *ScheduledPushUseCaseImpl) RunServerMode(config *entity.Config) error
 		log.Printf("[Server模式] 配置了包含应用监控，开始收集Server的应用监控数据")
 		
 		// 收集Redis指标
-		if config.AppMonitoring != nil && config.AppMonitoring.Redis != nil && config.AppMonitoring.Redis.Enabled {
+		if len(config.AppMonitoring.RedisTargets()) > 0 {
 			redisMetrics := spu.collectRedisMetrics(config)
-			if redisMetrics != nil {
+			if len(redisMetrics) > 0 {
 				serverMetrics.Redis = redisMetrics
 				log.Printf("[Server模式] 已收集Redis指标")
 			}
 		}
 
 		// 收集MySQL指标
-		if config.AppMonitoring != nil && config.AppMonitoring.MySQL != nil && config.AppMonitoring.MySQL.Enabled {
+		if len(config.AppMonitoring.MySQLTargets()) > 0 {
 			mysqlMetrics := spu.collectMySQLMetrics(config)
-			if mysqlMetrics != nil {
+			if len(mysqlMetrics) > 0 {
 				serverMetrics.MySQL = mysqlMetrics
 				log.Printf("[Server模式] 已收集MySQL指标")
 			}
@@ -509,6 +565,10 @@ func (spu *ScheduledPushUseCaseImpl) RunServerMode(config *entity.Config) error
 		Title:     serverTitle,
 		Timestamp: time.Now(),
 		Metrics:   serverMetrics,
+		Silences:  spu.silencer.Active(config, time.Now()),
+		Flapping:  spu.flappedSinceLastReport(),
+
+		DiskForecasts: spu.diskForecasts(),
 	}
 
 	// 检查clientDataList中是否已经有Server自己的数据（通过IP判断）
@@ -568,6 +628,28 @@ func (spu *ScheduledPushUseCaseImpl) RunServerMode(config *entity.Config) error
 	return nil
 }
 
+// flappedSinceLastReport 返回自上次定时推送以来发生过抖动的告警（首次推送统计最近 24 小时），并将统计起点更新为当前时间
+func (spu *ScheduledPushUseCaseImpl) flappedSinceLastReport() []entity.FlapRecord {
+	now := time.Now()
+	since := spu.lastReport
+	if since.IsZero() {
+		since = now.Add(-24 * time.Hour)
+	}
+	spu.lastReport = now
+	if spu.flapHistory == nil {
+		return nil
+	}
+	return spu.flapHistory.Flapped(since)
+}
+
+// diskForecasts 返回预计在 diskForecastReportHorizon 内写满的挂载点，未启用写满预测时为空
+func (spu *ScheduledPushUseCaseImpl) diskForecasts() []entity.DiskForecast {
+	if spu.diskForecaster == nil {
+		return nil
+	}
+	return spu.diskForecaster.Forecasts(diskForecastReportHorizon)
+}
+
 // getHostIP 获取本机 IP 地址（优先获取非回环的IPv4地址）
 func getHostIP() string {
 	addrs, err := net.InterfaceAddrs()
@@ -130,7 +130,7 @@ func (tu *TickerUseCaseImpl) RunTickerReport(config *entity.Config) error {
 		return fmt.Errorf("收集定时器指标失败: %v", err)
 	}
 
-	systemMetrics := tu.systemMetricsService.CollectBasicMetrics()
+	systemMetrics := tu.systemMetricsService.CollectBasicMetrics(config)
 
 	title := tu.getTickerTitle(config)
 
@@ -169,6 +169,7 @@ func (tu *TickerUseCaseImpl) buildTickerReport(config *entity.Config, tickerMetr
 // TickerSchedulerImpl 定时器调度器实现
 type TickerSchedulerImpl struct {
 	tickerUseCase ticker.TickerUseCase
+	configMu      sync.RWMutex
 	config        *entity.Config
 	ticker        *time.Ticker
 	stopCh        chan struct{}
@@ -186,7 +187,7 @@ func NewTickerScheduler(tickerUseCase ticker.TickerUseCase) ticker.TickerSchedul
 
 // Start 启动定时器调度
 func (ts *TickerSchedulerImpl) Start(config *entity.Config, stopCh <-chan struct{}) error {
-	ts.config = config
+	ts.UpdateConfig(config)
 
 	// 每10秒检查一次是否到了告警时间，提高响应速度
 	ts.ticker = time.NewTicker(10 * time.Second)
@@ -196,13 +197,13 @@ func (ts *TickerSchedulerImpl) Start(config *entity.Config, stopCh <-chan struct
 
 		// 启动时立即检查一次，避免错过启动时间
 		log.Println("启动时检查告警时间...")
-		ts.executeTickerReportIfNeeded(config, "启动时匹配到告警时间，立即执行设备状态报告")
+		ts.executeTickerReportIfNeeded(ts.currentConfig(), "启动时匹配到告警时间，立即执行设备状态报告")
 
 		for {
 			select {
 			case <-ts.ticker.C:
-				// 检查所有ticker接口的告警时间
-				ts.executeTickerReportIfNeeded(config, "定时器触发：开始执行设备状态报告")
+				// 检查所有ticker接口的告警时间（每次读取最新配置，支持热加载）
+				ts.executeTickerReportIfNeeded(ts.currentConfig(), "定时器触发：开始执行设备状态报告")
 			case <-stopCh:
 				log.Println("定时器调度器收到停止信号")
 				return
@@ -216,9 +217,23 @@ func (ts *TickerSchedulerImpl) Start(config *entity.Config, stopCh <-chan struct
 	return nil
 }
 
+// UpdateConfig 更新调度器使用的配置（配置热加载时调用），下一次检查即生效
+func (ts *TickerSchedulerImpl) UpdateConfig(config *entity.Config) {
+	ts.configMu.Lock()
+	defer ts.configMu.Unlock()
+	ts.config = config
+}
+
+// currentConfig 返回当前生效的配置
+func (ts *TickerSchedulerImpl) currentConfig() *entity.Config {
+	ts.configMu.RLock()
+	defer ts.configMu.RUnlock()
+	return ts.config
+}
+
 // executeTickerReportIfNeeded 如果需要则执行定时器报告
 func (ts *TickerSchedulerImpl) executeTickerReportIfNeeded(config *entity.Config, logPrefix string) {
-    if config.AppMonitoring == nil || config.AppMonitoring.Tickers == nil {
+    if config.AppMonitoring == nil || !config.AppMonitoring.Enabled || config.AppMonitoring.Tickers == nil || !config.AppMonitoring.Tickers.Enabled {
         return
     }
     for _, tickerConfig := range config.AppMonitoring.Tickers.TickerInterfaces {
@@ -10,20 +10,25 @@ type HostCollector interface {
 	GetCPUPercent() (float64, error)
 	GetMemoryUsage() (float64, uint64, uint64, error)
 	GetDiskUsage() (float64, uint64, uint64, error)
+	// GetMountUsages returns space and inode usage of every real mount point selected by cfg (nil means defaults).
+	GetMountUsages(cfg *entity.DiskMountConfig) ([]entity.MountMetrics, error)
 	GetDiskIORate() (float64, float64, error)
 	GetNetworkRate() (float64, float64, error)
+	// GetInterfaceRates returns per-interface byte/packet/error/drop rates of the interfaces selected by cfg (nil means all).
+	GetInterfaceRates(cfg *entity.NetworkInterfaceConfig) ([]entity.InterfaceMetrics, error)
 	// GetTopProcesses returns top N processes by CPU and Memory
 	GetTopProcesses(n int) ([]entity.ProcessInfo, []entity.ProcessInfo, error)
 }
 
 // RedisCollector defines capabilities for collecting Redis service metrics.
+// Instances are addressed by name; an empty name refers to the single `redis` instance.
 type RedisCollector interface {
-	// Init prepares underlying connections according to global config.
-	Init() error
+	// Init prepares the connection of the named instance according to global config.
+	Init(name string) error
 	// GetClients returns number of client connections (excluding self when possible).
-	GetClients() (int, error)
+	GetClients(name string) (int, error)
 	// GetClientsDetail returns detailed client list (excluding self when possible).
-	GetClientsDetail() ([]entity.ClientInfo, error)
+	GetClientsDetail(name string) ([]entity.ClientInfo, error)
 	// Close releases resources.
 	Close()
 }
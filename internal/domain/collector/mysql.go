@@ -6,27 +6,28 @@ import (
 )
 
 // MySQLCollector MySQL监控数据收集器接口
+// 各方法按实例名称区分多个 MySQL，空名称表示 mysql 单实例
 type MySQLCollector interface {
-	// Init 初始化MySQL连接
-	Init() error
+	// Init 初始化（或重新建立）指定实例的MySQL连接
+	Init(name string) error
 	
 	// GetConnectionMetrics 获取连接与会话指标
-	GetConnectionMetrics(ctx context.Context) (ConnectionMetrics, error)
+	GetConnectionMetrics(ctx context.Context, name string) (ConnectionMetrics, error)
 	
 	// GetQueryPerformanceMetrics 获取查询性能指标
-	GetQueryPerformanceMetrics(ctx context.Context) (QueryPerformanceMetrics, error)
+	GetQueryPerformanceMetrics(ctx context.Context, name string) (QueryPerformanceMetrics, error)
 	
 	// GetBufferPoolMetrics 获取InnoDB Buffer Pool指标
-	GetBufferPoolMetrics(ctx context.Context) (BufferPoolMetrics, error)
+	GetBufferPoolMetrics(ctx context.Context, name string) (BufferPoolMetrics, error)
 	
 	// GetReplicationMetrics 获取复制状态指标
-	GetReplicationMetrics(ctx context.Context) (ReplicationMetrics, error)
+	GetReplicationMetrics(ctx context.Context, name string) (ReplicationMetrics, error)
 	
 	// GetLockMetrics 获取锁与阻塞指标
-	GetLockMetrics(ctx context.Context) (LockMetrics, error)
+	GetLockMetrics(ctx context.Context, name string) (LockMetrics, error)
 	
 	// GetTransactionMetrics 获取事务与日志指标
-	GetTransactionMetrics(ctx context.Context) (TransactionMetrics, error)
+	GetTransactionMetrics(ctx context.Context, name string) (TransactionMetrics, error)
 	
 	// Close 关闭连接
 	Close() error
@@ -44,9 +45,10 @@ type ConnectionMetrics struct {
 
 // QueryPerformanceMetrics 查询性能指标
 type QueryPerformanceMetrics struct {
-	QPS                int     `yaml:"qps"`                  // 每秒查询数
-	TPS                int     `yaml:"tps"`                  // 每秒事务数
-	SlowQueries        int     `yaml:"slow_queries"`         // 慢查询数量
+	QPS                float64 `yaml:"qps"`                  // 每秒查询数（Com_select 的每秒增量，只统计 SELECT）
+	TPS                float64 `yaml:"tps"`                  // 每秒事务数（Com_commit + Com_rollback 相对上次采样的增量）
+	SlowQueries        int     `yaml:"slow_queries"`         // 慢查询数量（自 MySQL 启动以来累计）
+	SlowQueriesPerMinute float64 `yaml:"slow_queries_per_minute"` // 最近一分钟新增的慢查询数
 	P95ResponseTime    int     `yaml:"p95_response_time"`    // P95响应时间（毫秒）
 	P99ResponseTime    int     `yaml:"p99_response_time"`    // P99响应时间（毫秒）
 	Questions          int     `yaml:"questions"`            // 总查询数
@@ -83,9 +85,11 @@ type ReplicationMetrics struct {
 
 // LockMetrics 锁与阻塞指标
 type LockMetrics struct {
-	RowLockWaits       int     `yaml:"row_lock_waits"`        // 行锁等待次数
+	RowLockWaits       int     `yaml:"row_lock_waits"`        // 行锁等待次数（累计）
 	RowLockTime        int     `yaml:"row_lock_time"`         // 行锁等待时间（毫秒）
-	Deadlocks          int     `yaml:"deadlocks"`             // 死锁次数
+	Deadlocks          int     `yaml:"deadlocks"`             // 死锁次数（累计）
+	RowLockWaitsPerMinute float64 `yaml:"row_lock_waits_per_minute"` // 最近一分钟新增的行锁等待次数
+	DeadlocksPerHour   float64 `yaml:"deadlocks_per_hour"`    // 最近一小时新增的死锁次数
 	TableLocksWaited   int     `yaml:"table_locks_waited"`    // 表锁等待次数
 	TableLocksImmediate int   `yaml:"table_locks_immediate"`  // 立即获得表锁次数
 }
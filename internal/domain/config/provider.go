@@ -3,7 +3,17 @@ package config
 import "GWatch/internal/entity"
 
 // Provider 定义配置提供者的领域接口
-// 仅暴露读取能力，加载与来源由基础设施层实现
+// 暴露读取与热加载能力，加载与来源由基础设施层实现
 type Provider interface {
 	GetConfig() *entity.Config
+
+	// Reload 重新加载配置，校验通过后原子替换；失败时保留旧配置并返回错误
+	Reload() error
+
+	// Subscribe 注册配置变更回调，新配置生效后按注册顺序调用
+	Subscribe(fn func(cfg *entity.Config))
+
+	// Watch 监听配置来源变化并自动重新加载，stopCh 关闭时退出
+	// onError 在重新加载失败时被调用（例如发送告警通知）
+	Watch(stopCh <-chan struct{}, onError func(err error))
 }
@@ -1,10 +1,37 @@
 package monitoring
 
-import "GWatch/internal/entity"
+import (
+	"GWatch/internal/entity"
+	"time"
+)
 
 // Decision 表示一次阈值判断的结果（不包含消息体与发送）
+// Target 标识告警对象（如 Redis 实例名称），同类型不同对象的告警独立防抖；单对象告警为空
+// Value 为触发时的观测值（如 CPU 使用率），监控失败类告警为 0
+// Severity 为告警级别（warning/critical），超过 critical 阈值时为 critical
+// Escalated 由 Policy 设置：critical 告警持续超过 escalation.after 仍未恢复，本次通知为升级通知
+// Message 为自定义规则渲染好的告警消息，内置告警为空（发送时按类型拼接）
 type Decision struct {
-	Type entity.AlertType
+	Type      entity.AlertType
+	Target    string
+	Value     float64
+	Severity  string
+	Escalated bool
+	Message   string
+	Flapping  bool // 抖动通知：告警频繁切换，此后暂停通知直到状态稳定
+}
+
+// Recovery 表示一个已恢复的告警：告警发出后连续若干周期未再命中
+type Recovery struct {
+	Type   entity.AlertType
+	Target string
+	Peak   float64                          // 告警期间最严重的观测值（过低类告警为最低值）
+	Record *entity.ScheduledPushAlertRecord // 告警记录，已标记为已解决
+}
+
+// Duration 告警从发出到恢复的持续时长
+func (r Recovery) Duration() time.Duration {
+	return r.Record.Duration()
 }
 
 // Evaluator 负责根据配置与指标进行阈值判断，返回触发的决策
@@ -15,13 +42,24 @@ type Evaluator interface {
 
 // TriggeredAlert 携带具体的告警类型与详细消息
 type TriggeredAlert struct {
-	Type    entity.AlertType
-	Message string
+	Type     entity.AlertType
+	Target   string
+	Severity string // 告警级别，用于告警路由与消息颜色
+	Message  string
+
+	// Escalated 升级通知：额外发送到 escalation 配置的渠道与 @ 列表
+	Escalated bool
+
+	// Flapping 抖动通知：该告警频繁切换，之后暂停通知直到状态稳定
+	Flapping bool
 }
 
 // Formatter 负责将告警信息与指标拼成可读文本（例如 Markdown）
 type Formatter interface {
 	Build(title string, cfg *entity.Config, metrics *entity.SystemMetrics, alerts []TriggeredAlert) string
+
+	// BuildRecovery 构建告警恢复通知
+	BuildRecovery(title string, cfg *entity.Config, metrics *entity.SystemMetrics, recoveries []Recovery) string
 }
 
 // TickerFormatter 格式化ticker报告内容
@@ -29,13 +67,93 @@ type TickerFormatter interface {
 	BuildTickerReport(title string, cfg *entity.Config, tickerMetrics *entity.TickerMetrics, systemMetrics *entity.SystemMetrics) string
 }
 
-// Policy 将 monitor 层的阈值判断结果，结合防抖/连续计数等策略，输出最终需要通知的告警类型
+// Policy 将 monitor 层的阈值判断结果，结合防抖/连续计数等策略，输出最终需要通知的告警
 type Policy interface {
-	Apply(cfg *entity.Config, metrics *entity.SystemMetrics, decisions []Decision) []entity.AlertType
+	Apply(cfg *entity.Config, metrics *entity.SystemMetrics, decisions []Decision) []Decision
+
+	// Resolve 以本周期的全部命中决策为依据，返回已发出告警中连续若干周期未再命中的恢复事件
+	// 应与 Apply 使用同一批决策、每个周期调用一次
+	Resolve(cfg *entity.Config, decisions []Decision) []Recovery
+
+	// Discard 撤销刚由 Apply 发出、但最终没有发送（如命中白名单）的告警事件，避免之后发送无对应告警的恢复通知
+	Discard(d Decision)
+
+	// MarkSilenced 记录刚由 Apply 发出的告警命中的静默 ID，空字符串表示已正常发送
+	// 最近一次发出被静默的告警恢复时不发送恢复通知
+	MarkSilenced(d Decision, silenceID string)
+}
+
+// FlapHistory 告警抖动记录，用于定时报告
+type FlapHistory interface {
+	// Flapped 返回 since 之后处于抖动状态的告警（包括仍在抖动的），按开始时间排序
+	Flapped(since time.Time) []entity.FlapRecord
+}
+
+// BaselineStore 异常检测基线持久化：基线需要数天到数周的数据才能建立，重启后继续使用
+type BaselineStore interface {
+	// Restore 启动时从基线文件恢复，文件不存在时从空基线开始学习
+	Restore()
+
+	// Run 定期保存，直到 stopCh 关闭
+	Run(stopCh <-chan struct{})
+
+	// Save 立即保存，用于退出前
+	Save() error
+}
+
+// DiskForecaster 磁盘写满预测，用于定时报告
+type DiskForecaster interface {
+	// Forecasts 返回最近一次评估中使用率持续增长、预计在 within 内写满的挂载点，按写满时间排序
+	Forecasts(within time.Duration) []entity.DiskForecast
+}
+
+// AlertHistory 告警历史：记录每次发送、恢复、静默与被抑制的告警
+type AlertHistory interface {
+	// Record 追加告警事件，history 未启用时不做任何事
+	Record(cfg *entity.Config, events ...entity.AlertEvent) error
+}
+
+// Silencer 判断告警是否处于静默期（维护窗口或临时静默）
+type Silencer interface {
+	// Match 返回 at 时刻命中该告警的静默，未命中返回 nil
+	Match(cfg *entity.Config, alertType entity.AlertType, target string, at time.Time) *entity.Silence
+
+	// Active 返回 at 时刻生效的全部静默（维护窗口在前）
+	Active(cfg *entity.Config, at time.Time) []entity.Silence
 }
 
 // Notifier 定义消息通知的能力
 type Notifier interface {
+	// Send 发送到全部已启用的通知渠道
 	Send(title string, markdown string) error
+
+	// SendTo 发送到指定名称的通知渠道，atMobiles 与渠道自身的 @ 列表合并
+	SendTo(channels []string, atMobiles []string, title string, markdown string) error
+}
+
+
+// OutboxStats 通知发件箱统计
+type OutboxStats struct {
+	Pending int // 等待发送或重试
+	Failed  int // 超过最大尝试次数，不再重试
+}
+
+// Outbox 通知发件箱：持久化待发送的通知，后台按退避策略重试，重启后继续发送
+type Outbox interface {
+	// Run 运行后台重试，直到 stopCh 关闭
+	Run(stopCh <-chan struct{})
+
+	Stats() OutboxStats
 }
 
+// PolicyStateStore 告警策略状态持久化：重启后恢复连续计数、最近发送时间与未恢复的告警，避免重复发送
+type PolicyStateStore interface {
+	// Restore 启动时从状态文件恢复，文件不存在或已过期时从空状态开始
+	Restore()
+
+	// Run 定期保存，直到 stopCh 关闭
+	Run(stopCh <-chan struct{})
+
+	// Save 立即保存，用于退出前
+	Save() error
+}
@@ -13,6 +13,9 @@ type ScheduledPushScheduler interface {
 	// Stop 停止全局定时推送调度
 	Stop() error
 	
+	// UpdateConfig 更新调度使用的配置（配置热加载）
+	UpdateConfig(config *entity.Config)
+	
 	// IsTimeToPush 检查是否到了推送时间
 	IsTimeToPush(pushTimes []string) bool
 }
@@ -13,6 +13,9 @@ type TickerScheduler interface {
 	// Stop 停止定时器调度
 	Stop() error
 	
+	// UpdateConfig 更新调度使用的配置（配置热加载）
+	UpdateConfig(config *entity.Config)
+	
 	// IsTimeToAlert 检查是否到了告警时间
 	IsTimeToAlert(alertTimes []string) bool
 }
@@ -7,6 +7,9 @@ import (
 
 // Config 总配置结构
 type Config struct {
+	// include 目录：该目录下的 *.yml 按文件名顺序合并到本配置（映射深度合并、列表追加）
+	IncludeDir        string                 `yaml:"include_dir,omitempty"`
+	
 	// 主机类监控配置
 	HostMonitoring    *HostMonitoringConfig `yaml:"host_monitoring,omitempty"`    // 主机类监控，nil表示不监控
 	
@@ -18,6 +21,19 @@ type Config struct {
 	
 	// 通用配置
 	DingTalk          DingTalkConfig         `yaml:"dingtalk"`
+	Notifiers         []NotifierConfig       `yaml:"notifiers,omitempty"` // 额外的通知渠道，与 dingtalk 同时生效
+	Routing           *RoutingConfig         `yaml:"routing,omitempty"`   // 告警路由，未配置时所有告警发送到全部渠道
+	AlertPolicies     []AlertPolicy          `yaml:"alert_policies,omitempty"` // 按告警类型/对象覆盖连续次数、重复间隔与恢复次数
+	InhibitRules      []InhibitRule          `yaml:"inhibit_rules,omitempty"`  // 源告警命中时抑制相关的告警，避免一次故障发出多条告警
+	Outbox            OutboxConfig           `yaml:"outbox"`              // 通知发件箱：持久化待发送通知，失败后重试
+	PolicyState       PolicyStateConfig      `yaml:"policy_state"`        // 告警策略状态持久化，重启后不重复发送未恢复的告警
+	History           HistoryConfig          `yaml:"history"`             // 告警历史：发送、恢复、静默与抑制的告警写入本地数据库，供 history 命令查询
+	Templates         TemplatesConfig        `yaml:"templates,omitempty"` // 自定义消息模板文件
+	Silences          SilencesConfig         `yaml:"silences"`            // 维护窗口与临时静默
+	Escalation        *EscalationConfig      `yaml:"escalation,omitempty"` // critical 告警长时间未恢复时升级通知
+	Flapping          *FlappingConfig        `yaml:"flapping,omitempty"`   // 告警抖动检测，nil 表示不检测
+	Anomaly           *AnomalyConfig         `yaml:"anomaly,omitempty"`    // 动态基线异常检测，nil 表示不检测
+	Rules             RulesConfig            `yaml:"rules,omitempty"`      // 表达式自定义告警规则
 	Log               LogConfig              `yaml:"log"`
 	WhiteProcessList  []string               `yaml:"whiteProcessList"`
 	JavaAppDumpScript *JavaAppDumpScript     `yaml:"javaAppDumpScript,omitempty"`
@@ -34,6 +50,9 @@ type HostMonitoringConfig struct {
 	AlertInterval        time.Duration `yaml:"alert_interval"`
 	AlertTitle           string        `yaml:"alert_title"`
 	
+	// 告警发出后连续多少个周期未再命中视为恢复，并发送恢复通知
+	RecoveryThreshold int `yaml:"recovery_threshold"`
+	
 	// CPU监控
 	CPUThreshold float64 `yaml:"cpu_threshold"`
 	
@@ -43,7 +62,92 @@ type HostMonitoringConfig struct {
 	// 磁盘监控
 	DiskThreshold float64 `yaml:"disk_threshold"`
 	
-	// 网络监控（默认启用，无需额外配置）
+	// critical 级别阈值（%），超过时告警级别为 critical，0 表示不区分（始终为 warning）
+	CPUCriticalThreshold    float64 `yaml:"cpu_critical_threshold,omitempty"`
+	MemoryCriticalThreshold float64 `yaml:"memory_critical_threshold,omitempty"`
+	DiskCriticalThreshold   float64 `yaml:"disk_critical_threshold,omitempty"`
+	
+	// 挂载点监控：默认统计所有真实文件系统的挂载点（过滤 tmpfs/overlay 等伪文件系统）
+	Disks *DiskMountConfig `yaml:"disks,omitempty"`
+	
+	// 网络监控（默认启用，无需额外配置）；network 可按网卡过滤并配置带宽/错误/丢包阈值
+	Network *NetworkInterfaceConfig `yaml:"network,omitempty"`
+}
+
+// NetworkInterfaceConfig 网卡监控配置
+type NetworkInterfaceConfig struct {
+	// 网卡名通配模式（path.Match 语法，如 "veth*"），include 为空表示全部网卡
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+	
+	// 带宽使用率告警阈值（%，收/发任一方向占链路速率的比例），0 表示不检查
+	BandwidthThreshold float64 `yaml:"bandwidth_threshold"`
+	// 带宽使用率 critical 阈值（%），0 表示不区分
+	BandwidthCriticalThreshold float64 `yaml:"bandwidth_critical_threshold,omitempty"`
+	// 错误包告警阈值（个/秒，收发合计），0 表示不检查
+	ErrorRateThreshold float64 `yaml:"error_rate_threshold"`
+	// 丢包告警阈值（个/秒，收发合计），0 表示不检查
+	DropRateThreshold float64 `yaml:"drop_rate_threshold"`
+	
+	// 按网卡指定链路速率（Mbps），覆盖系统上报的速率，如 {"eth0": 1000}；虚拟网卡通常需要手动指定
+	Speeds map[string]int `yaml:"speeds,omitempty"`
+}
+
+// DiskMountConfig 挂载点监控配置
+type DiskMountConfig struct {
+	// 挂载点通配模式（path.Match 语法，如 "/data*"），include 为空表示全部挂载点
+	Include []string `yaml:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty"`
+	
+	// 默认忽略的伪文件系统之外，额外保留/排除的文件系统类型（如 include_fstypes: [tmpfs]）
+	IncludeFSTypes []string `yaml:"include_fstypes,omitempty"`
+	ExcludeFSTypes []string `yaml:"exclude_fstypes,omitempty"`
+	
+	// inode 使用率告警阈值（%）
+	InodeThreshold float64 `yaml:"inode_threshold"`
+	// inode 使用率 critical 阈值（%），0 表示不区分
+	InodeCriticalThreshold float64 `yaml:"inode_critical_threshold,omitempty"`
+	
+	// 按挂载点覆盖 disk_threshold，如 {"/data": 90}
+	Thresholds map[string]float64 `yaml:"thresholds,omitempty"`
+	// 按挂载点覆盖 disk_critical_threshold，如 {"/data": 98}
+	CriticalThresholds map[string]float64 `yaml:"critical_thresholds,omitempty"`
+
+	// 按使用率趋势预测写满时间，nil 表示不预测
+	Forecast *DiskForecastConfig `yaml:"forecast,omitempty"`
+}
+
+// DiskForecastConfig 磁盘写满预测：对最近 window 内各挂载点的使用率做线性拟合，
+// 按增长速度推算的写满时间短于 horizon 时产生 disk_fill_predicted 告警
+type DiskForecastConfig struct {
+	// 参与拟合的采样时间窗口
+	Window time.Duration `yaml:"window"`
+	// 采样至少覆盖该时长才开始预测，避免刚启动时少量采样的误判
+	MinSpan time.Duration `yaml:"min_span"`
+	// 预计写满时间短于该时长时告警
+	Horizon time.Duration `yaml:"horizon"`
+	// 预计写满时间短于该时长时为 critical，0 表示不区分
+	CriticalHorizon time.Duration `yaml:"critical_horizon,omitempty"`
+}
+
+// MountThreshold 返回指定挂载点的磁盘使用率阈值：优先 disks.thresholds，其次 disk_threshold
+func (h *HostMonitoringConfig) MountThreshold(mount string) float64 {
+	if h.Disks != nil {
+		if t, ok := h.Disks.Thresholds[mount]; ok {
+			return t
+		}
+	}
+	return h.DiskThreshold
+}
+
+// MountCriticalThreshold 返回指定挂载点的 critical 阈值：优先 disks.critical_thresholds，其次 disk_critical_threshold
+func (h *HostMonitoringConfig) MountCriticalThreshold(mount string) float64 {
+	if h.Disks != nil {
+		if t, ok := h.Disks.CriticalThresholds[mount]; ok {
+			return t
+		}
+	}
+	return h.DiskCriticalThreshold
 }
 
 // AppMonitoringConfig 应用层类监控配置
@@ -51,12 +155,18 @@ type AppMonitoringConfig struct {
 	// 是否启用应用监控
 	Enabled bool `yaml:"enabled"` // 是否启用应用监控
 	
-	// Redis监控
+	// Redis监控（单实例写法，实例名称可为空）
 	Redis *RedisConfig `yaml:"redis,omitempty"`
 	
-	// MySQL监控
+	// 多个命名 Redis 实例，每个实例独立连接、独立阈值
+	RedisInstances []RedisConfig `yaml:"redis_instances,omitempty"`
+	
+	// MySQL监控（单实例写法，实例名称可为空）
 	MySQL *MySQLMonitoringConfig `yaml:"mysql,omitempty"`
 	
+	// 多个命名 MySQL 实例（如一主多从），每个实例独立连接、独立阈值与复制配置
+	MySQLInstances []MySQLMonitoringConfig `yaml:"mysql_instances,omitempty"`
+	
 	// HTTP接口监控
 	HTTP *HTTPMonitoringConfig `yaml:"http,omitempty"`
 	
@@ -69,6 +179,12 @@ type MySQLMonitoringConfig struct {
 	// 是否启用MySQL监控
 	Enabled bool `yaml:"enabled"` // 是否启用MySQL监控
 	
+	// 实例名称，用于告警消息与指标区分（mysql_instances 中必填）
+	Name string `yaml:"name,omitempty"`
+	
+	// 实例角色: primary（默认）/ replica，只有从库才检查复制状态
+	Role string `yaml:"role,omitempty"`
+	
 	// 连接配置
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
@@ -87,6 +203,22 @@ type MySQLMonitoringConfig struct {
 	Replication *ReplicationConfig `yaml:"replication,omitempty"`
 }
 
+// IsReplica 是否为从库：role 为 replica，或未声明 role 时沿用 replication.enabled
+func (m *MySQLMonitoringConfig) IsReplica() bool {
+	if m.Role != "" {
+		return m.Role == "replica"
+	}
+	return m.Replication != nil && m.Replication.Enabled
+}
+
+// ReplicationDelayThreshold 复制延迟告警阈值（秒）：优先 thresholds.replication_delay_warning_seconds，其次 replication.delay_warning_seconds
+func (m *MySQLMonitoringConfig) ReplicationDelayThreshold() int {
+	if m.Thresholds.ReplicationDelayWarningSeconds > 0 || m.Replication == nil {
+		return m.Thresholds.ReplicationDelayWarningSeconds
+	}
+	return m.Replication.DelayWarningSeconds
+}
+
 // MySQLThresholds MySQL监控阈值（精简版）
 type MySQLThresholds struct {
 	// 连接数使用率（%）
@@ -101,6 +233,16 @@ type MySQLThresholds struct {
 	ReplicationDelayWarningSeconds int `yaml:"replication_delay_warning_seconds"`
 	// 每小时死锁次数
 	DeadlocksPerHourWarning int `yaml:"deadlocks_per_hour_warning"`
+	// 每分钟行锁等待增量告警阈值，0 表示不检查
+	RowLockWaitsPerMinuteWarning int `yaml:"row_lock_waits_per_minute_warning,omitempty"`
+	// 每秒查询数（Com_select，只统计 SELECT）告警阈值，0 表示不检查
+	QPSWarning int `yaml:"qps_warning,omitempty"`
+	
+	// critical 级别阈值，超过时告警级别为 critical，0 表示不区分
+	MaxConnectionsUsageCritical     float64 `yaml:"max_connections_usage_critical,omitempty"`
+	ThreadsRunningCritical          int     `yaml:"threads_running_critical,omitempty"`
+	ReplicationDelayCriticalSeconds int     `yaml:"replication_delay_critical_seconds,omitempty"`
+	QPSCritical                     int     `yaml:"qps_critical,omitempty"`
 }
 
 // ConnectionThresholds 连接与会话监控阈值
@@ -152,6 +294,7 @@ type HTTPMonitoringConfig struct {
 	Enabled bool `yaml:"enabled"` // 是否启用HTTP监控
 	
 	ErrorThreshold int             `yaml:"error_threshold"`
+	CriticalErrorThreshold int      `yaml:"critical_error_threshold,omitempty"` // 异常接口数超过该值时告警级别为 critical，0 表示不区分
 	Interval        time.Duration   `yaml:"interval"`
 	Interfaces      []HTTPInterface `yaml:"interfaces"`
 }
@@ -188,6 +331,9 @@ type RedisConfig struct {
 	// 是否启用Redis监控
 	Enabled bool `yaml:"enabled"` // 是否启用Redis监控
 	
+	// 实例名称，用于告警消息与指标区分（redis_instances 中必填）
+	Name string `yaml:"name,omitempty"`
+	
 	Addr         string        `yaml:"addr"`
 	Password     string        `yaml:"password"`
 	DB           int           `yaml:"db"`
@@ -199,6 +345,63 @@ type RedisConfig struct {
 	// Redis监控阈值
 	MinClients   int `yaml:"min_clients"`
 	MaxClients   int `yaml:"max_clients"`
+	
+	// 连接数超过该值时告警级别为 critical，0 表示不区分
+	MaxClientsCritical int `yaml:"max_clients_critical,omitempty"`
+}
+
+// RedisTargets 返回所有已启用的 Redis 实例（redis 单实例在前，其后为 redis_instances），应用监控未启用时返回 nil
+func (a *AppMonitoringConfig) RedisTargets() []RedisConfig {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	var targets []RedisConfig
+	if a.Redis != nil && a.Redis.Enabled {
+		targets = append(targets, *a.Redis)
+	}
+	for _, r := range a.RedisInstances {
+		if r.Enabled {
+			targets = append(targets, r)
+		}
+	}
+	return targets
+}
+
+// MySQLTargets 返回所有已启用的 MySQL 实例（mysql 单实例在前，其后为 mysql_instances），应用监控未启用时返回 nil
+func (a *AppMonitoringConfig) MySQLTargets() []MySQLMonitoringConfig {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	var targets []MySQLMonitoringConfig
+	if a.MySQL != nil && a.MySQL.Enabled {
+		targets = append(targets, *a.MySQL)
+	}
+	for _, m := range a.MySQLInstances {
+		if m.Enabled {
+			targets = append(targets, m)
+		}
+	}
+	return targets
+}
+
+// MySQLTarget 按实例名称查找已启用的 MySQL 实例
+func (a *AppMonitoringConfig) MySQLTarget(name string) (MySQLMonitoringConfig, bool) {
+	for _, m := range a.MySQLTargets() {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return MySQLMonitoringConfig{}, false
+}
+
+// RedisTarget 按实例名称查找已启用的 Redis 实例
+func (a *AppMonitoringConfig) RedisTarget(name string) (RedisConfig, bool) {
+	for _, r := range a.RedisTargets() {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return RedisConfig{}, false
 }
 
 // DingTalkConfig 钉钉配置
@@ -208,6 +411,318 @@ type DingTalkConfig struct {
 	AtMobiles  []string `yaml:"at_mobiles"`
 }
 
+// DingTalkRateLimitPerMinute 钉钉机器人每分钟最多发送 20 条消息，超出会被限流
+const DingTalkRateLimitPerMinute = 20
+
+// 通知渠道类型
+const (
+	NotifierDingTalk = "dingtalk" // 钉钉机器人
+	NotifierEmail    = "email"    // SMTP 邮件
+	NotifierWeCom    = "wecom"    // 企业微信机器人
+	NotifierFeishu   = "feishu"   // 飞书/Lark 机器人
+	NotifierSlack    = "slack"    // Slack incoming webhook
+	NotifierWebhook  = "webhook"  // 通用 JSON webhook
+)
+
+// NotifierConfig 单个通知渠道配置，按 type 使用对应字段
+type NotifierConfig struct {
+	// 渠道名称，必填且不能重复，用于日志与告警路由
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Enabled bool   `yaml:"enabled"`
+	
+	// 机器人/webhook 地址（dingtalk/wecom/feishu/slack/webhook）
+	WebhookURL string `yaml:"webhook_url,omitempty"`
+	// 加签密钥（dingtalk/feishu），为空表示不加签
+	Secret string `yaml:"secret,omitempty"`
+	// 需要 @ 的手机号（dingtalk/wecom）
+	AtMobiles []string `yaml:"at_mobiles,omitempty"`
+	// 附加的 HTTP 请求头（webhook），如 Authorization
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// 每分钟最多发送条数，0 表示不限制；dingtalk 默认 20（钉钉机器人限制）
+	RateLimitPerMinute int `yaml:"rate_limit_per_minute,omitempty"`
+	
+	// SMTP 邮件（email）
+	SMTPHost string   `yaml:"smtp_host,omitempty"`
+	SMTPPort int      `yaml:"smtp_port,omitempty"`
+	SMTPTLS  bool     `yaml:"smtp_tls,omitempty"` // 直接使用 TLS 连接（465 端口默认开启），否则在服务器支持时使用 STARTTLS
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from,omitempty"`
+	To       []string `yaml:"to,omitempty"`
+}
+
+// NotificationChannels 返回所有生效的通知渠道：dingtalk 配置了 webhook_url 时作为名为 "dingtalk" 的渠道排在最前，其后为已启用的 notifiers
+func (c *Config) NotificationChannels() []NotifierConfig {
+	var channels []NotifierConfig
+	if c.DingTalk.WebhookURL != "" {
+		channels = append(channels, NotifierConfig{
+			Name:       NotifierDingTalk,
+			Type:       NotifierDingTalk,
+			Enabled:    true,
+			WebhookURL: c.DingTalk.WebhookURL,
+			Secret:     c.DingTalk.Secret,
+			AtMobiles:  c.DingTalk.AtMobiles,
+
+			RateLimitPerMinute: DingTalkRateLimitPerMinute,
+		})
+	}
+	for _, n := range c.Notifiers {
+		if n.Enabled {
+			channels = append(channels, n)
+		}
+	}
+	return channels
+}
+
+// BaseInterval 基础监控（主机、Redis、MySQL 等）的检查间隔，未启用主机监控或未配置时为 5 秒
+func (c *Config) BaseInterval() time.Duration {
+	if h := c.HostMonitoring; h != nil && h.Enabled && h.Interval > 0 {
+		return h.Interval
+	}
+	return 5 * time.Second
+}
+
+// HTTPInterval HTTP 接口监控的检查间隔，未启用或未配置时为 10 秒
+func (c *Config) HTTPInterval() time.Duration {
+	if a := c.AppMonitoring; a != nil && a.Enabled && a.HTTP != nil && a.HTTP.Enabled && a.HTTP.Interval > 0 {
+		return a.HTTP.Interval
+	}
+	return 10 * time.Second
+}
+
+// OutboxConfig 通知发件箱配置：每条通知先写入本地目录，发送成功后删除，失败按指数退避重试，重启后继续发送
+type OutboxConfig struct {
+	// 是否启用，未配置时默认启用
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// 待发送/发送失败通知的存放目录
+	Dir string `yaml:"dir"`
+
+	// 最多尝试次数，超过后标记为发送失败
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// 首次重试间隔与最大重试间隔，每次失败后翻倍并加入随机抖动
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+
+	// 该时间内发送过的相同内容（渠道、标题、正文、@ 列表均相同）不再重复发送
+	DedupWindow time.Duration `yaml:"dedup_window"`
+}
+
+// IsEnabled 发件箱是否启用
+func (o OutboxConfig) IsEnabled() bool {
+	return o.Enabled == nil || *o.Enabled
+}
+
+// PolicyStateConfig 告警策略状态持久化：连续计数、最近发送时间与未恢复的告警定期保存到本地文件，退出时再保存一次，启动时恢复
+type PolicyStateConfig struct {
+	// 是否启用，未配置时默认启用
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// 状态文件路径
+	File string `yaml:"file"`
+
+	// 定期保存的间隔
+	SaveInterval time.Duration `yaml:"save_interval"`
+
+	// 状态文件超过该时长未更新视为过期，启动时不恢复（停机期间告警可能早已恢复或失效）
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// IsEnabled 告警策略状态持久化是否启用
+func (p PolicyStateConfig) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
+}
+
+// HistoryConfig 告警历史：每次发送、恢复、静默与被抑制的告警写入本地 bbolt 数据库文件
+type HistoryConfig struct {
+	// 是否启用，未配置时默认启用
+	Enabled *bool `yaml:"enabled,omitempty"`
+
+	// 数据库文件路径
+	File string `yaml:"file"`
+
+	// 记录保留时长，更早的记录自动清理
+	Retention time.Duration `yaml:"retention"`
+}
+
+// IsEnabled 告警历史是否启用
+func (h HistoryConfig) IsEnabled() bool {
+	return h.Enabled == nil || *h.Enabled
+}
+
+// TemplatesConfig 自定义消息模板文件（Go text/template 语法），为空使用内置模板
+type TemplatesConfig struct {
+	Alert           string `yaml:"alert,omitempty"`            // 实时告警
+	Recovery        string `yaml:"recovery,omitempty"`         // 告警恢复
+	TickerReport    string `yaml:"ticker_report,omitempty"`    // ticker 设备状态报告
+	ScheduledReport string `yaml:"scheduled_report,omitempty"` // 全局定时推送（server 聚合）报告
+}
+
+// EscalationConfig 告警升级：critical 告警持续 after 仍未恢复、也未被静默（确认）时，再通知一次并扩大通知范围
+type EscalationConfig struct {
+	After     time.Duration `yaml:"after"`
+	Channels  []string      `yaml:"channels,omitempty"`   // 升级通知额外发送的渠道，与原路由渠道合并
+	AtMobiles []string      `yaml:"at_mobiles,omitempty"` // 升级通知额外 @ 的手机号
+}
+
+// FlappingConfig 告警抖动检测：window 内告警与正常之间切换达到 threshold 次视为抖动，
+// 发送一次抖动通知后暂停该告警的通知（包括恢复通知），状态保持不变超过 stable_for 后解除
+type FlappingConfig struct {
+	Window    time.Duration `yaml:"window"`
+	Threshold int           `yaml:"threshold"`
+	StableFor time.Duration `yaml:"stable_for"`
+}
+
+// 异常检测支持的指标
+const (
+	AnomalyCPU              = "cpu"
+	AnomalyMemory           = "memory"
+	AnomalyNetworkDownload  = "network_download"
+	AnomalyNetworkUpload    = "network_upload"
+	AnomalyRedisClients     = "redis_clients"
+	AnomalyMySQLQPS         = "mysql_qps"
+	AnomalyMySQLThreads     = "mysql_threads_running"
+	AnomalyHTTPResponseTime = "http_response_time"
+)
+
+// AnomalyMetrics 全部可检测的指标
+var AnomalyMetrics = []string{
+	AnomalyCPU, AnomalyMemory, AnomalyNetworkDownload, AnomalyNetworkUpload,
+	AnomalyRedisClients, AnomalyMySQLQPS, AnomalyMySQLThreads, AnomalyHTTPResponseTime,
+}
+
+// AnomalyConfig 动态基线异常检测：按"星期 + 小时"学习各指标的 EWMA 均值与标准差（该时段数据不足时使用按小时的基线），
+// 观测值偏离基线超过 sigma 个标准差并持续 for 时长后产生 anomaly 告警；基线定期保存到 file，重启后继续使用
+type AnomalyConfig struct {
+	// 参与检测的指标，为空表示全部
+	Metrics []string `yaml:"metrics,omitempty"`
+
+	// 偏离多少个标准差视为异常
+	Sigma float64 `yaml:"sigma"`
+	// 偏离超过该标准差倍数时为 critical，0 表示不区分
+	CriticalSigma float64 `yaml:"critical_sigma,omitempty"`
+
+	// 持续异常多久后告警
+	For time.Duration `yaml:"for"`
+
+	// EWMA 权重 (0, 1]：每个时段结束时新数据所占的比重，越大基线适应越快
+	Alpha float64 `yaml:"alpha"`
+
+	// 某个时段至少学习多少次后才用于检测（按小时的基线每天一次，按星期 + 小时的基线每周一次）
+	MinPeriods int `yaml:"min_periods"`
+
+	// 基线文件路径与定期保存的间隔
+	File         string        `yaml:"file"`
+	SaveInterval time.Duration `yaml:"save_interval"`
+}
+
+// Detects 是否检测该指标
+func (a *AnomalyConfig) Detects(metric string) bool {
+	if len(a.Metrics) == 0 {
+		return true
+	}
+	for _, m := range a.Metrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// RulesConfig 自定义告警规则：与内置阈值判断并行生效，disable_builtin 为 true 时只使用自定义规则
+type RulesConfig struct {
+	DisableBuiltin bool        `yaml:"disable_builtin,omitempty"`
+	Alerts         []AlertRule `yaml:"alerts,omitempty"`
+}
+
+// AlertRule 表达式告警规则：expr 持续成立 for 时长后触发，告警类型为 rule、告警对象为规则名称
+type AlertRule struct {
+	Name     string        `yaml:"name"`
+	Expr     string        `yaml:"expr"`               // 布尔表达式，如 mysql.connections.usage > 85 && http["api"].response_time > 2s
+	For      time.Duration `yaml:"for,omitempty"`      // 表达式需持续成立的时长，0 表示命中即触发
+	Severity string        `yaml:"severity,omitempty"` // info/warning/critical，默认 warning
+	Message  string        `yaml:"message,omitempty"`  // 告警消息模板（text/template），为空时使用规则名称与表达式
+}
+
+// SilencesConfig 告警静默配置：周期性维护窗口与运行时创建的临时静默，命中的告警照常记录但不发送
+type SilencesConfig struct {
+	// 临时静默的保存文件（silence 命令写入，运行中的进程文件变化后自动重新读取）
+	File string `yaml:"file"`
+
+	// 周期性维护窗口
+	MaintenanceWindows []MaintenanceWindow `yaml:"maintenance_windows,omitempty"`
+}
+
+// MaintenanceWindow 周期性维护窗口：按 cron 表达式在指定时区开始，持续 duration
+type MaintenanceWindow struct {
+	Name     string        `yaml:"name"`
+	Schedule string        `yaml:"schedule"` // 5 字段 cron 表达式（分 时 日 月 周），如 "0 2 * * 6" 表示每周六 2:00
+	Duration time.Duration `yaml:"duration"`
+	Timezone string        `yaml:"timezone,omitempty"` // IANA 时区名，如 "Asia/Shanghai"，为空使用本机时区
+
+	// 匹配条件，均为空时静默全部告警
+	SilenceMatchers `yaml:",inline"`
+
+	Comment string `yaml:"comment,omitempty"`
+}
+
+// AlertPolicy 按告警类型与对象覆盖告警策略，按顺序匹配，第一个命中的生效；未设置（为 0）的项使用默认值
+// 默认值：连续次数 CPU/内存/HTTP 为 host_monitoring.consecutive_threshold、其余为 1；
+// 重复间隔为 host_monitoring.alert_interval（HTTP 为 app_monitoring.http.interval）；恢复次数为 host_monitoring.recovery_threshold
+type AlertPolicy struct {
+	AlertTypes           []string      `yaml:"alert_types,omitempty"` // 告警类型，支持通配符，为空表示不限
+	Targets              []string      `yaml:"targets,omitempty"`     // 告警对象（实例名、挂载点、网卡、规则名等），支持通配符，为空表示不限
+	ConsecutiveThreshold int           `yaml:"consecutive_threshold,omitempty"` // 连续多少个周期超阈值才告警
+	RepeatInterval       time.Duration `yaml:"repeat_interval,omitempty"`       // 告警未恢复时重复发送的最小间隔
+	RecoveryThreshold    int           `yaml:"recovery_threshold,omitempty"`    // 连续多少个周期未超阈值视为恢复
+}
+
+// InhibitRule 告警抑制规则：源告警在本周期命中时，同一对象（或同一主机）上的目标告警不发送，只记录
+// 如 Redis 连接异常时抑制该实例的连接数过低，主机 CPU 过高时抑制 HTTP 接口异常
+type InhibitRule struct {
+	Name        string   `yaml:"name"`
+	SourceTypes []string `yaml:"source_types"` // 源告警类型，支持通配符，如 "redis_error"
+	TargetTypes []string `yaml:"target_types"` // 被抑制的告警类型，支持通配符，如 "redis_*"
+	// 源告警与目标告警的关联方式：target（默认）要求告警对象相同，如同一 Redis 实例；host 为同一主机上的任意对象
+	Equal string `yaml:"equal,omitempty"`
+}
+
+// 抑制规则的关联方式
+const (
+	InhibitEqualTarget = "target"
+	InhibitEqualHost   = "host"
+)
+
+// InhibitID 被抑制的告警在告警记录中的静默 ID
+func InhibitID(rule string) string {
+	return "inhibit:" + rule
+}
+
+// RoutingConfig 告警路由配置：按顺序匹配 routes，未命中任何路由的告警使用 default
+type RoutingConfig struct {
+	Routes  []AlertRoute `yaml:"routes"`
+	Default AlertRoute   `yaml:"default"` // 仅 channels 与 at_mobiles 生效
+}
+
+// AlertRoute 单条告警路由，匹配条件为空表示不限；多个条件同时满足才命中
+type AlertRoute struct {
+	Name string `yaml:"name"`
+
+	// 匹配条件（通配模式，path.Match 语法）
+	AlertTypes []string `yaml:"alert_types,omitempty"` // 告警类型，如 "mysql_*"、"http_error"
+	Severities []string `yaml:"severities,omitempty"`  // 告警级别：info/warning/critical
+	Hosts      []string `yaml:"hosts,omitempty"`       // 主机名或主机IP
+	Targets    []string `yaml:"targets,omitempty"`     // 告警对象：HTTP接口名、网卡名、挂载点、Redis/MySQL实例名
+
+	// 发送目标
+	Channels  []string `yaml:"channels,omitempty"`   // 通知渠道名称（dingtalk 或 notifiers[].name），为空使用 default 的渠道
+	AtMobiles []string `yaml:"at_mobiles,omitempty"` // 额外 @ 的手机号，与渠道自身的 at_mobiles 合并
+
+	// 命中后是否继续匹配后续路由（默认命中即停止）
+	Continue bool `yaml:"continue,omitempty"`
+}
 
 // HTTPInterface HTTP接口监控配置
 type HTTPInterface struct {
@@ -1,5 +1,10 @@
 package entity
 
+import (
+	"fmt"
+	"math"
+)
+
 type AlertType string
 
 // 所有告警类型的常量定义
@@ -10,6 +15,8 @@ const (
 	MemErr          AlertType = "mem_error"          // 内存监控失败
 	DiskHigh        AlertType = "disk_high"          // 磁盘过高
 	DiskErr         AlertType = "disk_error"         // 磁盘监控失败
+	DiskInodeHigh   AlertType = "disk_inode_high"    // 磁盘inode使用率过高
+	DiskFillPredicted AlertType = "disk_fill_predicted" // 磁盘预计写满
 	DiskIOReadHigh  AlertType = "disk_io_read_high"  // 磁盘读IO过高
 	DiskIOWriteHigh AlertType = "disk_io_write_high" // 磁盘写IO过高
 	RedisHigh       AlertType = "redis_high"         // Redis连接数过高
@@ -29,10 +36,45 @@ const (
 	MySQLTransLong  AlertType = "mysql_trans_long"   // MySQL长时间未提交事务
 	
 	NetworkErr      AlertType = "network_error"      // 网络监控失败
+	NetBandwidthHigh AlertType = "net_bandwidth_high" // 网卡带宽使用率过高
+	NetErrorsHigh   AlertType = "net_errors_high"    // 网卡错误包过多
+	NetDropsHigh    AlertType = "net_drops_high"     // 网卡丢包过多
 	HTTPErr         AlertType = "http_error"         // HTTP接口监控失败
+	RuleAlert       AlertType = "rule"               // 自定义表达式规则
+	Anomaly         AlertType = "anomaly"            // 指标偏离动态基线
 	Info            AlertType = "info"
 )
 
+// 告警级别
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// SeverityText 告警级别中文名
+var SeverityText = map[string]string{
+	SeverityInfo:     "提示",
+	SeverityWarning:  "警告",
+	SeverityCritical: "严重",
+}
+
+// severityRank 告警级别的严重程度排序
+var severityRank = map[string]int{SeverityInfo: 1, SeverityWarning: 2, SeverityCritical: 3}
+
+// SeverityAbove 观测值超过 critical 阈值（阈值大于 0 时）为 critical，否则为 warning
+func SeverityAbove(value, critical float64) string {
+	if critical > 0 && value > critical {
+		return SeverityCritical
+	}
+	return SeverityWarning
+}
+
+// MoreSevere 告警级别 a 是否比 b 更严重
+func MoreSevere(a, b string) bool {
+	return severityRank[a] > severityRank[b]
+}
+
 // AlertTypeText 告警类型中文描述映射表
 var AlertTypeText = map[AlertType]string{
 	CPUHigh:         "CPU使用率过高",
@@ -41,6 +83,8 @@ var AlertTypeText = map[AlertType]string{
 	MemErr:          "内存监控失败",
 	DiskHigh:        "磁盘使用率过高",
 	DiskErr:         "磁盘监控失败",
+	DiskInodeHigh:   "磁盘inode使用率过高",
+	DiskFillPredicted: "磁盘预计写满",
 	DiskIOReadHigh:  "磁盘读IO过高",
 	DiskIOWriteHigh: "磁盘写IO过高",
 	RedisHigh:       "Redis连接数过高",
@@ -57,11 +101,17 @@ var AlertTypeText = map[AlertType]string{
 	MySQLDeadlock:   "MySQL死锁",
 	MySQLTransLong:  "MySQL长时间未提交事务",
 	NetworkErr:      "网络监控失败",
+	NetBandwidthHigh: "网卡带宽使用率过高",
+	NetErrorsHigh:   "网卡错误包过多",
+	NetDropsHigh:    "网卡丢包过多",
 	HTTPErr:         "HTTP接口监控失败",
+	RuleAlert:       "自定义规则",
+	Anomaly:         "指标偏离基线",
 	Info:            "信息",
 }
 
-// AlertTypeRequiresConsecutive 是否需要"连续超标"才触发的类型（用于 shouldTriggerAlert）
+// AlertTypeRequiresConsecutive 默认需要"连续超标"才触发的类型，连续次数取 host_monitoring.consecutive_threshold
+// 其余类型默认首次超标即触发；均可由 alert_policies 按类型/对象覆盖
 var AlertTypeRequiresConsecutive = map[AlertType]bool{
 	CPUHigh: true,
 	MemHigh: true,
@@ -71,12 +121,47 @@ var AlertTypeRequiresConsecutive = map[AlertType]bool{
 	MemErr:          false,
 	DiskHigh:        false,
 	DiskErr:         false,
+	DiskInodeHigh:   false,
+	DiskFillPredicted: false,
 	DiskIOReadHigh:  false,
 	DiskIOWriteHigh: false,
 	RedisHigh:       false,
 	RedisLow:        false,
 	RedisErr:        false,
 	NetworkErr:      false,
+	NetBandwidthHigh: false,
+	NetErrorsHigh:   false,
+	NetDropsHigh:    false,
+}
+
+// alertValueFormat 告警观测值的显示格式，未列出的类型（如监控失败类）没有观测值
+var alertValueFormat = map[AlertType]string{
+	CPUHigh:          "%.2f%%",
+	MemHigh:          "%.2f%%",
+	DiskHigh:         "%.2f%%",
+	DiskInodeHigh:    "%.2f%%",
+	DiskFillPredicted: "%.1f 小时后写满",
+	RedisHigh:        "%.0f 个连接",
+	RedisLow:         "%.0f 个连接",
+	MySQLConnHigh:    "%.2f%%",
+	MySQLThreadsHigh: "%.0f 个线程",
+	MySQLQPSHigh:     "%.0f 次/秒",
+	MySQLSlowQuery:   "%.0f 条/分钟",
+	MySQLLockWait:    "%.0f 次/分钟",
+	MySQLBufferLow:   "%.2f%%",
+	MySQLReplDelay:   "%.0f 秒",
+	MySQLDeadlock:    "%.0f 次/小时",
+	NetBandwidthHigh: "%.2f%%",
+	NetErrorsHigh:    "%.2f 个/秒",
+	NetDropsHigh:     "%.2f 个/秒",
+	HTTPErr:          "%.0f 个异常接口",
+}
+
+// alertTypeLowerIsWorse 数值越低越严重的告警类型，峰值取最低值
+var alertTypeLowerIsWorse = map[AlertType]bool{
+	RedisLow:       true,
+	MySQLBufferLow: true,
+	DiskFillPredicted: true,
 }
 
 // 获取告警中文名
@@ -85,4 +170,34 @@ func (a AlertType) String() string {
 		return text
 	}
 	return "未知告警"
-}
\ No newline at end of file
+}
+
+// Describe 返回带目标名称的告警中文名，如 "Redis连接数过高 [cache]"；target 为空时与 String() 相同
+func (a AlertType) Describe(target string) string {
+	return TargetLabel(a.String(), target)
+}
+
+// TargetLabel 在名称后追加目标（实例/挂载点/网卡等）标识，target 为空时原样返回
+func TargetLabel(name, target string) string {
+	if target == "" {
+		return name
+	}
+	return name + " [" + target + "]"
+}
+
+// FormatValue 按告警类型格式化观测值，如 "93.20%"；没有观测值的类型返回空
+func (a AlertType) FormatValue(v float64) string {
+	format, ok := alertValueFormat[a]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(format, v)
+}
+
+// Worse 返回两个观测值中更严重的一个（过低类告警取较小值，其余取较大值）
+func (a AlertType) Worse(x, y float64) float64 {
+	if alertTypeLowerIsWorse[a] {
+		return math.Min(x, y)
+	}
+	return math.Max(x, y)
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// DiskForecast 挂载点写满预测：按最近一段时间的使用率线性趋势推算
+type DiskForecast struct {
+	Mount       string        `json:"mount"`
+	Percent     float64       `json:"percent"`       // 最近一次采样的使用率（%）
+	RatePerHour float64       `json:"rate_per_hour"` // 拟合的使用率增长速度（%/小时）
+	ETA         time.Duration `json:"eta"`           // 预计多久后写满
+	FullAt      time.Time     `json:"full_at"`       // 预计写满的时间
+}
+
+// ETAText 预计写满时间的中文描述，如 "1天3小时"、"5小时20分钟"
+func (f DiskForecast) ETAText() string {
+	d := f.ETA.Round(time.Minute)
+	days, hours, minutes := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d天%d小时", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%d小时%d分钟", hours, minutes)
+	default:
+		return fmt.Sprintf("%d分钟", minutes)
+	}
+}
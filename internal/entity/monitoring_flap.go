@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// FlapRecord 告警抖动记录：告警在短时间内频繁在告警与正常之间切换，期间暂停该告警的通知
+type FlapRecord struct {
+	Type        AlertType `json:"type"`
+	Target      string    `json:"target,omitempty"`
+	Start       time.Time `json:"start"`         // 判定为抖动的时间
+	End         time.Time `json:"end,omitempty"` // 状态稳定、解除抖动的时间，仍在抖动时为零值
+	Transitions int       `json:"transitions"`   // 从判定窗口开始到解除为止的状态切换次数
+}
+
+// Active 是否仍在抖动
+func (r *FlapRecord) Active() bool {
+	return r.End.IsZero()
+}
+
+// OverlapsSince since 之后是否处于抖动状态
+func (r *FlapRecord) OverlapsSince(since time.Time) bool {
+	return r.Active() || r.End.After(since)
+}
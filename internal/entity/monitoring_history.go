@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// 告警历史事件类型
+const (
+	AlertEventFired     = "fired"     // 告警已发送（含重复发送、升级与抖动通知）
+	AlertEventResolved  = "resolved"  // 告警已恢复
+	AlertEventSilenced  = "silenced"  // 命中静默，只记录不发送
+	AlertEventInhibited = "inhibited" // 被抑制规则抑制，只记录不发送
+)
+
+// AlertEvent 告警历史记录
+type AlertEvent struct {
+	Time       time.Time     `json:"time"`
+	Event      string        `json:"event"`
+	Type       AlertType     `json:"type"`
+	Severity   string        `json:"severity"`
+	Host       string        `json:"host"`
+	IP         string        `json:"ip,omitempty"`
+	Target     string        `json:"target,omitempty"`
+	Value      float64       `json:"value"` // 触发时的观测值，恢复事件为告警期间的峰值
+	Message    string        `json:"message"`
+	SilencedBy string        `json:"silenced_by,omitempty"` // 命中的静默 ID 或抑制规则（inhibit:名称）
+	Escalated  bool          `json:"escalated,omitempty"`
+	Flapping   bool          `json:"flapping,omitempty"`
+	FiredAt    time.Time     `json:"fired_at,omitempty"` // 恢复事件：告警首次发出的时间
+	Duration   time.Duration `json:"duration,omitempty"` // 恢复事件：告警持续时长
+}
+
+// HistoryQuery 告警历史查询条件，零值条件不过滤
+// Types/Hosts/Targets 支持通配符（path.Match 语法），Hosts 匹配主机名或IP
+type HistoryQuery struct {
+	Since   time.Time
+	Until   time.Time
+	Events  []string
+	Types   []string
+	Hosts   []string
+	Targets []string
+	Limit   int // 只返回最近的 Limit 条，0 表示不限制
+}
@@ -10,8 +10,8 @@ type SystemMetrics struct {
 	Memory    MemoryMetrics
 	Disk      DiskMetrics
 	Network   NetworkMetrics
-	Redis     RedisMetrics
-	MySQL     MySQLMetrics
+	Redis     []RedisMetrics
+	MySQL     []MySQLMetrics
 	HTTP      HTTPMetrics
 }
 
@@ -29,7 +29,7 @@ type MemoryMetrics struct {
 	Error   error
 }
 
-// DiskMetrics 磁盘指标
+// DiskMetrics 磁盘指标（Percent/UsedGB/TotalGB 为根分区 "/"，Mounts 为各挂载点明细）
 type DiskMetrics struct {
 	Percent     float64
 	UsedGB      uint64
@@ -37,17 +37,57 @@ type DiskMetrics struct {
 	ReadKBps    float64
 	WriteKBps   float64
 	Error       error
+	Mounts      []MountMetrics
+	MountsError error
 }
 
-// NetworkMetrics 网络指标
-type NetworkMetrics struct {
-	DownloadKBps float64
-	UploadKBps   float64
-	Error        error
+// MountMetrics 单个挂载点的空间与 inode 使用情况
+type MountMetrics struct {
+	Mount         string
+	Device        string
+	FSType        string
+	Percent       float64
+	UsedGB        uint64
+	TotalGB       uint64
+	InodesUsed    uint64
+	InodesTotal   uint64
+	InodesPercent float64
 }
 
-// RedisMetrics Redis指标
+// NetworkMetrics 网络指标（DownloadKBps/UploadKBps 为全部网卡合计，Interfaces 为各网卡明细）
+type NetworkMetrics struct {
+	DownloadKBps    float64
+	UploadKBps      float64
+	Error           error
+	Interfaces      []InterfaceMetrics
+	InterfacesError error
+}
+
+// InterfaceMetrics 单个网卡的收发速率（两次采样之间的平均值）
+type InterfaceMetrics struct {
+	Name          string
+	RecvKBps      float64
+	SentKBps      float64
+	RecvPps       float64 // 包/秒
+	SentPps       float64
+	ErrInPs       float64 // 错误包/秒
+	ErrOutPs      float64
+	DropInPs      float64 // 丢包/秒
+	DropOutPs     float64
+	SpeedMbps     int     // 链路速率，未知时为 0
+	BandwidthUsed float64 // 收/发中较大一方占链路速率的百分比，速率未知时为 0
+}
+
+// ErrorRate 收发错误包速率合计（个/秒）
+func (i InterfaceMetrics) ErrorRate() float64 { return i.ErrInPs + i.ErrOutPs }
+
+// DropRate 收发丢包速率合计（个/秒）
+func (i InterfaceMetrics) DropRate() float64 { return i.DropInPs + i.DropOutPs }
+
+// RedisMetrics Redis指标（单个实例）
 type RedisMetrics struct {
+	Name            string // 实例名称，redis 单实例写法时为空
+	Addr            string
 	ClientCount     int
 	ClientDetails   []ClientInfo
 	ConnectionError error
@@ -83,8 +123,13 @@ type HTTPInterfaceMetrics struct {
 	AllowedCodes []int
 }
 
-// MySQLMetrics MySQL监控指标
+// MySQLMetrics MySQL监控指标（单个实例）
 type MySQLMetrics struct {
+	// 实例名称（mysql 单实例写法时为空）、地址与角色
+	Name          string               `yaml:"name,omitempty"`
+	Addr          string               `yaml:"addr"`
+	Role          string               `yaml:"role,omitempty"`
+	
 	// 连接与会话指标
 	Connections     ConnectionMetrics     `yaml:"connections"`
 	
@@ -119,9 +164,12 @@ type ConnectionMetrics struct {
 
 // QueryPerformanceMetrics 查询性能指标
 type QueryPerformanceMetrics struct {
-	QPS                int     `yaml:"qps"`                  // 每秒查询数
-	TPS                int     `yaml:"tps"`                  // 每秒事务数
-	SlowQueries        int     `yaml:"slow_queries"`         // 慢查询数量
+	QPS                int     `yaml:"qps"`                  // 每秒查询数（取整，兼容按整数解析的旧版本服务端）
+	TPS                int     `yaml:"tps"`                  // 每秒事务数（取整，兼容按整数解析的旧版本服务端）
+	QueriesPerSecond   float64 `yaml:"queries_per_second"`   // 每秒查询数（Com_select 的每秒增量，只统计 SELECT）
+	TransactionsPerSecond float64 `yaml:"transactions_per_second"` // 每秒事务数（Com_commit + Com_rollback 的每秒增量）
+	SlowQueries        int     `yaml:"slow_queries"`         // 慢查询数量（自 MySQL 启动以来累计）
+	SlowQueriesPerMinute float64 `yaml:"slow_queries_per_minute"` // 最近一分钟新增的慢查询数
 	P95ResponseTime    int     `yaml:"p95_response_time"`    // P95响应时间（毫秒）
 	P99ResponseTime    int     `yaml:"p99_response_time"`    // P99响应时间（毫秒）
 	Questions          int     `yaml:"questions"`            // 总查询数
@@ -158,9 +206,11 @@ type ReplicationMetrics struct {
 
 // LockMetrics 锁与阻塞指标
 type LockMetrics struct {
-	RowLockWaits       int     `yaml:"row_lock_waits"`        // 行锁等待次数
+	RowLockWaits       int     `yaml:"row_lock_waits"`        // 行锁等待次数（累计）
 	RowLockTime        int     `yaml:"row_lock_time"`         // 行锁等待时间（毫秒）
-	Deadlocks          int     `yaml:"deadlocks"`             // 死锁次数
+	Deadlocks          int     `yaml:"deadlocks"`             // 死锁次数（累计）
+	RowLockWaitsPerMinute float64 `yaml:"row_lock_waits_per_minute"` // 最近一分钟新增的行锁等待次数
+	DeadlocksPerHour   float64 `yaml:"deadlocks_per_hour"`    // 最近一小时新增的死锁次数
 	TableLocksWaited   int     `yaml:"table_locks_waited"`    // 表锁等待次数
 	TableLocksImmediate int   `yaml:"table_locks_immediate"`  // 立即获得表锁次数
 }
@@ -185,7 +235,7 @@ type ScheduledPushMetrics struct {
 
 // AppMetrics 应用监控指标
 type AppMetrics struct {
-	Redis *RedisMetrics `json:"redis,omitempty"`
-	MySQL *MySQLMetrics `json:"mysql,omitempty"`
-	HTTP  *HTTPMetrics  `json:"http,omitempty"`
+	Redis []RedisMetrics `json:"redis,omitempty"`
+	MySQL []MySQLMetrics `json:"mysql,omitempty"`
+	HTTP  *HTTPMetrics   `json:"http,omitempty"`
 }
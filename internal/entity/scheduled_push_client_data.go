@@ -2,6 +2,8 @@
 package entity
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -20,6 +22,15 @@ type ClientMonitorData struct {
 	
 	// 监控指标
 	Metrics *ClientMetrics `json:"metrics"`
+
+	// 上报时生效的维护窗口与临时静默，在报告中列出
+	Silences []Silence `json:"silences,omitempty"`
+
+	// 本次报告周期内（自上次定时推送起）发生过抖动的告警，在报告中列出
+	Flapping []FlapRecord `json:"flapping,omitempty"`
+
+	// 预计在一周内写满的挂载点，在报告中列出
+	DiskForecasts []DiskForecast `json:"disk_forecasts,omitempty"`
 }
 
 // ClientMetrics 客户端监控指标（包含主机监控和应用监控）
@@ -31,11 +42,49 @@ type ClientMetrics struct {
 	Network *NetworkMetrics `json:"network,omitempty"`
 	
 	// 应用监控（可选，根据配置决定是否包含）
-	Redis *RedisMetrics `json:"redis,omitempty"`
-	MySQL *MySQLMetrics `json:"mysql,omitempty"`
+	Redis []RedisMetrics `json:"redis,omitempty"`
+	MySQL []MySQLMetrics `json:"mysql,omitempty"`
 	HTTP  *HTTPMetrics  `json:"http,omitempty"`
 }
 
+// UnmarshalJSON 兼容旧版本客户端的上报数据：redis/mysql 在支持多实例之前为单个对象，滚动升级期间按单个实例读取
+func (m *ClientMetrics) UnmarshalJSON(data []byte) error {
+	type plain ClientMetrics
+	aux := struct {
+		*plain
+		Redis json.RawMessage `json:"redis,omitempty"`
+		MySQL json.RawMessage `json:"mysql,omitempty"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := unmarshalOneOrMany(aux.Redis, &m.Redis); err != nil {
+		return fmt.Errorf("解析 redis 失败: %w", err)
+	}
+	if err := unmarshalOneOrMany(aux.MySQL, &m.MySQL); err != nil {
+		return fmt.Errorf("解析 mysql 失败: %w", err)
+	}
+	return nil
+}
+
+// unmarshalOneOrMany 解析数组或单个对象（视为只有一个元素的数组），null 或缺失时为空
+func unmarshalOneOrMany[T any](data json.RawMessage, out *[]T) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*out = nil
+		return nil
+	}
+	if data[0] == '[' {
+		return json.Unmarshal(data, out)
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err != nil {
+		return err
+	}
+	*out = []T{one}
+	return nil
+}
+
 // ClientDataKey 生成 Redis key 的辅助函数
 func ClientDataKey(hostIP string, timestamp time.Time) string {
 	return fmt.Sprintf("gwatch:client:%s:%d", hostIP, timestamp.Unix())
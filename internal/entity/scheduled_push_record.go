@@ -9,11 +9,13 @@ type ScheduledPushAlertRecord struct {
 	Title       string    `json:"title"`
 	Message     string    `json:"message"`
 	Timestamp   time.Time `json:"timestamp"`
-	Source      string    `json:"source"` // 固定为 "scheduled_push"
-	Severity    string    `json:"severity"` // 固定为 "info"
+	Source      string    `json:"source"` // 定时推送为 "scheduled_push"，实时告警为 "monitoring"
+	Severity    string    `json:"severity"` // 定时推送为 "info"，实时告警为告警期间的最高级别 "warning"/"critical"
 	PushTime    string    `json:"push_time"` // 推送时间点
 	IsResolved  bool      `json:"is_resolved"`
 	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	SilencedBy  string     `json:"silenced_by,omitempty"` // 最近一次发出时命中的静默 ID，此时只记录不发送
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"` // critical 告警升级通知的时间
 }
 
 // NewScheduledPushAlertRecord 创建新的全局定时推送告警记录
@@ -25,15 +27,36 @@ func NewScheduledPushAlertRecord(id, title, message, pushTime string) *Scheduled
 		Message:    message,
 		Timestamp:  now,
 		Source:     "scheduled_push",
-		Severity:   "info",
+		Severity:   SeverityInfo,
 		PushTime:   pushTime,
 		IsResolved: false,
 	}
 }
 
+// NewMonitoringAlertRecord 创建实时监控告警记录，Timestamp 为告警首次发出的时间
+func NewMonitoringAlertRecord(id, title, message string, firedAt time.Time) *ScheduledPushAlertRecord {
+	return &ScheduledPushAlertRecord{
+		ID:         id,
+		Title:      title,
+		Message:    message,
+		Timestamp:  firedAt,
+		Source:     "monitoring",
+		Severity:   SeverityWarning,
+		IsResolved: false,
+	}
+}
+
 // Resolve 标记告警为已解决
 func (r *ScheduledPushAlertRecord) Resolve() {
 	now := time.Now()
 	r.IsResolved = true
 	r.ResolvedAt = &now
 }
+
+// Duration 告警持续时长：已解决时为发出到解决的时间，未解决时为发出至今的时间
+func (r *ScheduledPushAlertRecord) Duration() time.Duration {
+	if r.IsResolved && r.ResolvedAt != nil {
+		return r.ResolvedAt.Sub(r.Timestamp)
+	}
+	return time.Since(r.Timestamp)
+}
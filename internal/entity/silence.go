@@ -0,0 +1,56 @@
+// Package entity internal/entity/silence.go
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// SilenceCreatedByMaintenance 由维护窗口生成的静默，CreatedBy 取该值
+const SilenceCreatedByMaintenance = "maintenance_window"
+
+// SilenceMatchers 静默的匹配条件（通配模式，path.Match 语法），为空表示不限；多个条件同时满足才命中
+type SilenceMatchers struct {
+	AlertTypes []string `yaml:"alert_types,omitempty" json:"alert_types,omitempty"` // 告警类型，如 "cpu_high"、"mysql_*"
+	Hosts      []string `yaml:"hosts,omitempty" json:"hosts,omitempty"`             // 主机名或主机IP
+	Targets    []string `yaml:"targets,omitempty" json:"targets,omitempty"`         // 告警对象：HTTP 接口名称、网卡、挂载点、实例名称等
+}
+
+// String 可读的匹配条件，如 "类型=cpu_high 对象=eth*"，全部为空时为 "全部告警"
+func (m SilenceMatchers) String() string {
+	var parts []string
+	if len(m.AlertTypes) > 0 {
+		parts = append(parts, "类型="+strings.Join(m.AlertTypes, ","))
+	}
+	if len(m.Hosts) > 0 {
+		parts = append(parts, "主机="+strings.Join(m.Hosts, ","))
+	}
+	if len(m.Targets) > 0 {
+		parts = append(parts, "对象="+strings.Join(m.Targets, ","))
+	}
+	if len(parts) == 0 {
+		return "全部告警"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Silence 静默：生效期间命中的告警照常记录，但不发送通知
+// 运行时通过 silence 命令创建的临时静默保存在 silences.file 中；维护窗口生效时也表示为一条 Silence
+type Silence struct {
+	ID string `json:"id"` // 临时静默为随机 ID，维护窗口为窗口名称
+	SilenceMatchers
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// ActiveAt 静默在 t 时刻是否生效
+func (s *Silence) ActiveAt(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// IsMaintenance 是否为维护窗口生成的静默
+func (s *Silence) IsMaintenance() bool {
+	return s.CreatedBy == SilenceCreatedByMaintenance
+}
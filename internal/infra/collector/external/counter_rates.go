@@ -0,0 +1,112 @@
+package external
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// counterRateWindow 最长的统计区间（每小时死锁次数），更早的采样不再保留
+const counterRateWindow = time.Hour
+
+// counterSample 计数器的一次采样，uptime 为采样时实例的 Uptime（秒），total 为扣除重启影响后自首次采样以来的累计增量
+type counterSample struct {
+	at     time.Time
+	uptime int
+	value  int
+	total  float64
+}
+
+// counterRates MySQL 累计计数器（SHOW GLOBAL STATUS）的采样，按 实例/计数器 保存最近一小时，
+// 用于计算每秒速率（QPS、TPS）与区间增量（每分钟慢查询、每小时死锁）
+type counterRates struct {
+	mu      sync.Mutex
+	samples map[string][]counterSample
+}
+
+func newCounterRates() *counterRates {
+	return &counterRates{samples: map[string][]counterSample{}}
+}
+
+// record 记录采样并丢弃统计区间之外的采样，返回该计数器当前的全部采样（按时间排序，最后一个为最新）
+// 不晚于上次采样的采样（并发采集时先查询、后记录）直接丢弃；Uptime 变小视为 MySQL 重启后从 0 重新计数，
+// 本次增量取当前值，未重启时计数器变小（如 FLUSH STATUS）不计增量
+func (c *counterRates) record(instance, counter string, at time.Time, uptime, value int) []counterSample {
+	key := instance + "/" + counter
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	samples := c.samples[key]
+	s := counterSample{at: at, uptime: uptime, value: value}
+	if n := len(samples); n > 0 {
+		last := samples[n-1]
+		if !at.After(last.at) {
+			return samples
+		}
+		delta := value - last.value
+		if uptime < last.uptime {
+			delta = value
+		} else if delta < 0 {
+			delta = 0
+		}
+		s.total = last.total + float64(delta)
+	}
+	samples = append(samples, s)
+	// 保留统计区间起点之前最近的一次采样，作为 increase 的起点
+	i := 0
+	for i+1 < len(samples) && at.Sub(samples[i+1].at) >= counterRateWindow {
+		i++
+	}
+	samples = samples[i:]
+	c.samples[key] = samples
+	return samples
+}
+
+// reset 丢弃实例的全部采样，用于实例地址变化（配置热加载）后避免新旧实例的计数器相减
+func (c *counterRates) reset(instance string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.samples {
+		if strings.HasPrefix(key, instance+"/") {
+			delete(c.samples, key)
+		}
+	}
+}
+
+// perSecond 每秒增量：以距最新采样至少 minGap 的最近一次采样为起点，
+// 避免并发采集产生的相隔极短的两次采样放大速率；没有足够早的采样（刚启动）时为 0
+func perSecond(samples []counterSample, minGap time.Duration) float64 {
+	n := len(samples)
+	if n < 2 {
+		return 0
+	}
+	last := samples[n-1]
+	for i := n - 2; i >= 0; i-- {
+		elapsed := last.at.Sub(samples[i].at)
+		if elapsed > 0 && elapsed >= minGap {
+			return (last.total - samples[i].total) / elapsed.Seconds()
+		}
+	}
+	return 0
+}
+
+// increase 最近 window 内的增量：以 window 之前最近的一次采样为起点；
+// 采样覆盖不足 window（刚启动）时为已有采样的增量，不做外推；采样间隔大于 window 时按 window 折算
+func increase(samples []counterSample, window time.Duration) float64 {
+	n := len(samples)
+	if n < 2 {
+		return 0
+	}
+	last := samples[n-1]
+	base := samples[0]
+	for _, s := range samples[1 : n-1] {
+		if last.at.Sub(s.at) < window {
+			break
+		}
+		base = s
+	}
+	inc := last.total - base.total
+	if elapsed := last.at.Sub(base.at); elapsed > window {
+		inc *= float64(window) / float64(elapsed)
+	}
+	return inc
+}
@@ -0,0 +1,136 @@
+package external
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// counterStep 一次采样：距起点的时间、实例地址变化（reset）、Uptime 与计数器值
+type counterStep struct {
+	at     time.Duration
+	reset  bool
+	uptime int
+	value  int
+}
+
+func TestCounterRates(t *testing.T) {
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		steps     []counterStep
+		gap       time.Duration // perSecond 的最小采样间隔（基础监控间隔）
+		perSecond float64
+		perMinute float64 // increase(…, time.Minute)
+	}{
+		{
+			name:      "正常递增",
+			steps:     []counterStep{{0, false, 100, 1000}, {5 * time.Second, false, 105, 1500}},
+			gap:       5 * time.Second,
+			perSecond: 100,
+			perMinute: 500,
+		},
+		{
+			name: "Uptime 变小视为重启，增量取重启后的值",
+			steps: []counterStep{
+				{0, false, 100, 1000}, {5 * time.Second, false, 105, 1500},
+				{10 * time.Second, false, 3, 200},
+			},
+			gap:       5 * time.Second,
+			perSecond: 40,
+			perMinute: 700,
+		},
+		{
+			name: "未重启时计数器变小不计增量",
+			steps: []counterStep{
+				{0, false, 100, 1000}, {5 * time.Second, false, 105, 1500},
+				{10 * time.Second, false, 110, 1200},
+			},
+			gap:       5 * time.Second,
+			perSecond: 0,
+			perMinute: 500,
+		},
+		{
+			name: "间隔小于基础监控间隔的采样以更早的采样为起点",
+			steps: []counterStep{
+				{0, false, 100, 1000}, {5 * time.Second, false, 105, 1500},
+				{5*time.Second + 10*time.Millisecond, false, 105, 1510},
+			},
+			gap:       5 * time.Second,
+			perSecond: 510 / 5.01,
+			perMinute: 510,
+		},
+		{
+			name:      "没有足够早的采样时速率为 0",
+			steps:     []counterStep{{0, false, 100, 1000}, {10 * time.Millisecond, false, 100, 1010}},
+			gap:       5 * time.Second,
+			perSecond: 0,
+			perMinute: 10,
+		},
+		{
+			name: "不晚于上次的采样被丢弃",
+			steps: []counterStep{
+				{0, false, 100, 1000}, {5 * time.Second, false, 105, 1500},
+				{4 * time.Second, false, 104, 1400},
+			},
+			gap:       5 * time.Second,
+			perSecond: 100,
+			perMinute: 500,
+		},
+		{
+			name: "实例地址变化后不与旧实例的采样相减",
+			steps: []counterStep{
+				{0, false, 100000, 900000}, {5 * time.Second, false, 100005, 900500},
+				{10 * time.Second, true, 50, 300}, {15 * time.Second, false, 55, 800},
+			},
+			gap:       5 * time.Second,
+			perSecond: 100,
+			perMinute: 500,
+		},
+		{
+			name: "采样间隔大于统计区间时按区间折算",
+			steps: []counterStep{
+				{0, false, 100, 0}, {2 * time.Minute, false, 220, 1200},
+			},
+			gap:       5 * time.Second,
+			perSecond: 10,
+			perMinute: 600,
+		},
+	}
+	for _, tt := range tests {
+		c := newCounterRates()
+		var samples []counterSample
+		for _, s := range tt.steps {
+			if s.reset {
+				c.reset("primary")
+			}
+			samples = c.record("primary", "com_select", base.Add(s.at), s.uptime, s.value)
+		}
+		if got := perSecond(samples, tt.gap); math.Abs(got-tt.perSecond) > 1e-6 {
+			t.Errorf("%s: perSecond = %v，期望 %v", tt.name, got, tt.perSecond)
+		}
+		if got := increase(samples, time.Minute); math.Abs(got-tt.perMinute) > 1e-6 {
+			t.Errorf("%s: increase = %v，期望 %v", tt.name, got, tt.perMinute)
+		}
+	}
+}
+
+func TestCounterRatesWindow(t *testing.T) {
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := newCounterRates()
+	var samples []counterSample
+	// 每分钟一次采样，每次增加 2，持续两小时
+	for i := 0; i <= 120; i++ {
+		samples = c.record("primary", "deadlocks", base.Add(time.Duration(i)*time.Minute), 1000+i*60, i*2)
+	}
+	// 只保留统计区间起点之前最近的一次采样
+	if first := samples[0].at; base.Add(2*time.Hour).Sub(first) != counterRateWindow {
+		t.Errorf("最早的采样为 %v，期望为一小时前", first)
+	}
+	if got := increase(samples, time.Hour); got != 120 {
+		t.Errorf("每小时增量 = %v，期望 120", got)
+	}
+	if got := increase(samples, time.Minute); got != 2 {
+		t.Errorf("每分钟增量 = %v，期望 2", got)
+	}
+}
@@ -6,34 +6,47 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"GWatch/internal/domain/collector"
 	"GWatch/internal/domain/config"
+	"GWatch/internal/entity"
 	_ "github.com/go-sql-driver/mysql"
 )
 
-// MySQLCollectorImpl MySQL监控数据收集器实现
+// MySQLCollectorImpl MySQL监控数据收集器实现，按实例名称管理多个连接池
 type MySQLCollectorImpl struct {
 	provider config.Provider
-	db       *sql.DB
+	mu       sync.RWMutex
+	dbs      map[string]*sql.DB
+	addrs    map[string]string      // 实例名称 -> 当前连接的 host:port
+	counters *counterRates          // 累计计数器的采样，用于计算速率
+	sampling map[string]*sync.Mutex // 实例名称 -> 采样锁，保证同一实例的计数器按查询顺序记录
 }
 
 // NewMySQLCollector 创建MySQL监控数据收集器
 func NewMySQLCollector(provider config.Provider) collector.MySQLCollector {
 	return &MySQLCollectorImpl{
 		provider: provider,
+		dbs:      map[string]*sql.DB{},
+		addrs:    map[string]string{},
+		counters: newCounterRates(),
+		sampling: map[string]*sync.Mutex{},
 	}
 }
 
-// Init 初始化MySQL连接
-func (c *MySQLCollectorImpl) Init() error {
+// Init 初始化（或重新建立）指定实例的MySQL连接，name 为空表示 mysql 单实例
+func (c *MySQLCollectorImpl) Init(name string) error {
 	cfg := c.provider.GetConfig()
-	if cfg == nil || cfg.AppMonitoring == nil || cfg.AppMonitoring.MySQL == nil {
+	if cfg == nil {
 		return fmt.Errorf("MySQL 配置未找到或未启用")
 	}
+	mysqlCfg, ok := cfg.AppMonitoring.MySQLTarget(name)
+	if !ok {
+		return fmt.Errorf("MySQL 配置未找到或未启用: %s", entity.TargetLabel("MySQL", name))
+	}
 
-	mysqlCfg := cfg.AppMonitoring.MySQL
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?timeout=%s&parseTime=true&loc=Local",
 		mysqlCfg.Username,
 		mysqlCfg.Password,
@@ -58,13 +71,79 @@ func (c *MySQLCollectorImpl) Init() error {
 		return fmt.Errorf("MySQL连接测试失败: %v", err)
 	}
 
-	c.db = db
+	// 重新初始化（如配置热加载、断线重连）时释放旧连接；地址变化后旧实例的计数器采样不再可比
+	addr := fmt.Sprintf("%s:%d", mysqlCfg.Host, mysqlCfg.Port)
+	c.mu.Lock()
+	if old := c.dbs[name]; old != nil {
+		old.Close()
+	}
+	c.dbs[name] = db
+	prev, ok := c.addrs[name]
+	c.addrs[name] = addr
+	c.mu.Unlock()
+	if ok && prev != addr {
+		unlock := c.lockSampling(name)
+		c.counters.reset(name)
+		unlock()
+	}
 	return nil
 }
 
+// db 返回指定实例的连接池，未初始化时返回错误
+func (c *MySQLCollectorImpl) db(name string) (*sql.DB, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	db := c.dbs[name]
+	if db == nil {
+		return nil, fmt.Errorf("%s 未初始化", entity.TargetLabel("MySQL", name))
+	}
+	return db, nil
+}
+
+// lockSampling 锁定实例的计数器采样，基础监控与 HTTP 合并检查可能并发采集同一实例，
+// 查询与记录需在同一把锁内完成，否则较早查询到的值可能在较新的值之后记录
+func (c *MySQLCollectorImpl) lockSampling(name string) (unlock func()) {
+	c.mu.Lock()
+	m := c.sampling[name]
+	if m == nil {
+		m = &sync.Mutex{}
+		c.sampling[name] = m
+	}
+	c.mu.Unlock()
+	m.Lock()
+	return m.Unlock
+}
+
+// rateGap 计算每秒速率的最小采样间隔，即基础监控的检查间隔
+func (c *MySQLCollectorImpl) rateGap() time.Duration {
+	cfg := c.provider.GetConfig()
+	if cfg == nil {
+		return 0
+	}
+	return cfg.BaseInterval()
+}
+
+// uptime 查询实例已运行的秒数，用于识别 MySQL 重启（累计计数器从 0 重新开始）
+func uptime(ctx context.Context, db *sql.DB) (int, error) {
+	var variableName string
+	var value string
+	if err := db.QueryRowContext(ctx, "SHOW GLOBAL STATUS LIKE 'Uptime'").Scan(&variableName, &value); err != nil {
+		return 0, fmt.Errorf("查询Uptime失败: %v", err)
+	}
+	val, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("解析Uptime值失败: %v", err)
+	}
+	return val, nil
+}
+
 // GetConnectionMetrics 获取连接与会话指标
-func (c *MySQLCollectorImpl) GetConnectionMetrics(ctx context.Context) (collector.ConnectionMetrics, error) {
+func (c *MySQLCollectorImpl) GetConnectionMetrics(ctx context.Context, name string) (collector.ConnectionMetrics, error) {
 	var metrics collector.ConnectionMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
 
 	// 获取连接相关指标
 	queries := map[string]interface{}{
@@ -76,10 +155,10 @@ func (c *MySQLCollectorImpl) GetConnectionMetrics(ctx context.Context) (collecto
 
 	for key, ptr := range queries {
 		query := fmt.Sprintf("SHOW GLOBAL STATUS LIKE '%s'", key)
-		var name string
+		var variableName string
 		var value string
 		
-		err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+		err := db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 		if err != nil {
 			// 如果查询失败，设置默认值0，不返回错误
 			switch ptr := ptr.(type) {
@@ -102,10 +181,10 @@ func (c *MySQLCollectorImpl) GetConnectionMetrics(ctx context.Context) (collecto
 
 	// 单独查询max_connections系统变量
 	query := "SHOW VARIABLES LIKE 'max_connections'"
-	var name string
+	var variableName string
 	var value string
 	
-	err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+	err = db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 	if err != nil {
 		fmt.Printf("[DEBUG] 查询max_connections失败，使用默认值0: %v\n", err)
 		metrics.MaxConnections = 0
@@ -129,13 +208,27 @@ func (c *MySQLCollectorImpl) GetConnectionMetrics(ctx context.Context) (collecto
 }
 
 // GetQueryPerformanceMetrics 获取查询性能指标
-func (c *MySQLCollectorImpl) GetQueryPerformanceMetrics(ctx context.Context) (collector.QueryPerformanceMetrics, error) {
+func (c *MySQLCollectorImpl) GetQueryPerformanceMetrics(ctx context.Context, name string) (collector.QueryPerformanceMetrics, error) {
 	var metrics collector.QueryPerformanceMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
+
+	unlock := c.lockSampling(name)
+	defer unlock()
+
+	// 先于计数器查询 Uptime：两次查询之间发生重启时，本次计数器变小但不计增量，下次采样再按重启处理
+	up, err := uptime(ctx, db)
+	if err != nil {
+		return metrics, err
+	}
 
-	// 获取查询性能相关指标
+	// 获取查询性能相关指标（均为自 MySQL 启动以来的累计值）；QPS 按 Com_select 计算，只统计 SELECT
+	var selects int
 	queries := map[string]interface{}{
 		"questions":     &metrics.Questions,
-		"com_select":    &metrics.QPS, // 简化处理，实际需要计算差值
+		"com_select":    &selects,
 		"com_commit":    &metrics.Committed,
 		"com_rollback":  &metrics.RolledBack,
 		"slow_queries":  &metrics.SlowQueries,
@@ -143,10 +236,10 @@ func (c *MySQLCollectorImpl) GetQueryPerformanceMetrics(ctx context.Context) (co
 
 	for key, ptr := range queries {
 		query := fmt.Sprintf("SHOW GLOBAL STATUS LIKE '%s'", key)
-		var name string
+		var variableName string
 		var value string
 		
-		err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+		err := db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 		if err != nil {
 			return metrics, fmt.Errorf("查询%s失败: %v", key, err)
 		}
@@ -162,8 +255,12 @@ func (c *MySQLCollectorImpl) GetQueryPerformanceMetrics(ctx context.Context) (co
 		}
 	}
 
-	// 计算TPS（简化处理）
-	metrics.TPS = metrics.Committed + metrics.RolledBack
+	// 由累计值计算速率
+	now, gap := time.Now(), c.rateGap()
+	metrics.QPS = perSecond(c.counters.record(name, "com_select", now, up, selects), gap)
+	metrics.TPS = perSecond(c.counters.record(name, "com_commit", now, up, metrics.Committed), gap) +
+		perSecond(c.counters.record(name, "com_rollback", now, up, metrics.RolledBack), gap)
+	metrics.SlowQueriesPerMinute = increase(c.counters.record(name, "slow_queries", now, up, metrics.SlowQueries), time.Minute)
 
 	// 响应时间需要从Performance Schema获取，这里简化处理
 	metrics.P95ResponseTime = 100 // 示例值
@@ -173,8 +270,12 @@ func (c *MySQLCollectorImpl) GetQueryPerformanceMetrics(ctx context.Context) (co
 }
 
 // GetBufferPoolMetrics 获取InnoDB Buffer Pool指标
-func (c *MySQLCollectorImpl) GetBufferPoolMetrics(ctx context.Context) (collector.BufferPoolMetrics, error) {
+func (c *MySQLCollectorImpl) GetBufferPoolMetrics(ctx context.Context, name string) (collector.BufferPoolMetrics, error) {
 	var metrics collector.BufferPoolMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
 
 	// 获取Buffer Pool相关指标
 	queries := map[string]interface{}{
@@ -190,10 +291,10 @@ func (c *MySQLCollectorImpl) GetBufferPoolMetrics(ctx context.Context) (collecto
 
 	for key, ptr := range queries {
 		query := fmt.Sprintf("SHOW GLOBAL STATUS LIKE '%s'", key)
-		var name string
+		var variableName string
 		var value string
 		
-		err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+		err := db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 		if err != nil {
 			return metrics, fmt.Errorf("查询%s失败: %v", key, err)
 		}
@@ -223,12 +324,16 @@ func (c *MySQLCollectorImpl) GetBufferPoolMetrics(ctx context.Context) (collecto
 }
 
 // GetReplicationMetrics 获取复制状态指标
-func (c *MySQLCollectorImpl) GetReplicationMetrics(ctx context.Context) (collector.ReplicationMetrics, error) {
+func (c *MySQLCollectorImpl) GetReplicationMetrics(ctx context.Context, name string) (collector.ReplicationMetrics, error) {
 	var metrics collector.ReplicationMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
 
 	// 检查是否为主从复制环境
 	query := "SHOW SLAVE STATUS"
-	rows, err := c.db.QueryContext(ctx, query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return metrics, fmt.Errorf("查询复制状态失败: %v", err)
 	}
@@ -290,8 +395,18 @@ func (c *MySQLCollectorImpl) GetReplicationMetrics(ctx context.Context) (collect
 }
 
 // GetLockMetrics 获取锁与阻塞指标
-func (c *MySQLCollectorImpl) GetLockMetrics(ctx context.Context) (collector.LockMetrics, error) {
+func (c *MySQLCollectorImpl) GetLockMetrics(ctx context.Context, name string) (collector.LockMetrics, error) {
 	var metrics collector.LockMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
+
+	unlock := c.lockSampling(name)
+	defer unlock()
+
+	// 与查询性能指标相同，先查询 Uptime；查询失败时不记录采样
+	up, upErr := uptime(ctx, db)
 
 	// 获取锁相关指标
 	queries := map[string]interface{}{
@@ -301,13 +416,14 @@ func (c *MySQLCollectorImpl) GetLockMetrics(ctx context.Context) (collector.Lock
 		"Table_locks_waited":         &metrics.TableLocksWaited,
 		"Table_locks_immediate":      &metrics.TableLocksImmediate,
 	}
+	found := map[string]bool{}
 
 	for key, ptr := range queries {
 		query := fmt.Sprintf("SHOW GLOBAL STATUS LIKE '%s'", key)
-		var name string
+		var variableName string
 		var value string
 		
-		err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+		err := db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 		if err != nil {
 			// 如果查询失败，设置默认值0，不返回错误
 			switch ptr := ptr.(type) {
@@ -316,6 +432,7 @@ func (c *MySQLCollectorImpl) GetLockMetrics(ctx context.Context) (collector.Lock
 			}
 			continue
 		}
+		found[key] = true
 		
 		val, err := strconv.Atoi(value)
 		if err != nil {
@@ -328,12 +445,25 @@ func (c *MySQLCollectorImpl) GetLockMetrics(ctx context.Context) (collector.Lock
 		}
 	}
 
+	// 由累计值计算区间增量；查询失败的计数器（如部分版本没有 Innodb_deadlocks）不记录采样，避免按 0 计算增量
+	now := time.Now()
+	if upErr == nil && found["Innodb_row_lock_waits"] {
+		metrics.RowLockWaitsPerMinute = increase(c.counters.record(name, "row_lock_waits", now, up, metrics.RowLockWaits), time.Minute)
+	}
+	if upErr == nil && found["Innodb_deadlocks"] {
+		metrics.DeadlocksPerHour = increase(c.counters.record(name, "deadlocks", now, up, metrics.Deadlocks), time.Hour)
+	}
+
 	return metrics, nil
 }
 
 // GetTransactionMetrics 获取事务与日志指标
-func (c *MySQLCollectorImpl) GetTransactionMetrics(ctx context.Context) (collector.TransactionMetrics, error) {
+func (c *MySQLCollectorImpl) GetTransactionMetrics(ctx context.Context, name string) (collector.TransactionMetrics, error) {
 	var metrics collector.TransactionMetrics
+	db, err := c.db(name)
+	if err != nil {
+		return metrics, err
+	}
 
 	// 获取事务相关指标
 	queries := map[string]interface{}{
@@ -343,10 +473,10 @@ func (c *MySQLCollectorImpl) GetTransactionMetrics(ctx context.Context) (collect
 
 	for key, ptr := range queries {
 		query := fmt.Sprintf("SHOW GLOBAL STATUS LIKE '%s'", key)
-		var name string
+		var variableName string
 		var value string
 		
-		err := c.db.QueryRowContext(ctx, query).Scan(&name, &value)
+		err := db.QueryRowContext(ctx, query).Scan(&variableName, &value)
 		if err != nil {
 			return metrics, fmt.Errorf("查询%s失败: %v", key, err)
 		}
@@ -364,14 +494,14 @@ func (c *MySQLCollectorImpl) GetTransactionMetrics(ctx context.Context) (collect
 
 	// 获取未提交事务数（需要查询INFORMATION_SCHEMA）
 	query := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.INNODB_TRX"
-	err := c.db.QueryRowContext(ctx, query).Scan(&metrics.UncommittedTransactions)
+	err = db.QueryRowContext(ctx, query).Scan(&metrics.UncommittedTransactions)
 	if err != nil {
 		return metrics, fmt.Errorf("查询未提交事务数失败: %v", err)
 	}
 
 	// 获取Binlog信息
 	query = "SHOW BINARY LOGS"
-	rows, err := c.db.QueryContext(ctx, query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return metrics, fmt.Errorf("查询Binlog信息失败: %v", err)
 	}
@@ -396,10 +526,16 @@ func (c *MySQLCollectorImpl) GetTransactionMetrics(ctx context.Context) (collect
 	return metrics, nil
 }
 
-// Close 关闭连接
+// Close 关闭所有实例的连接
 func (c *MySQLCollectorImpl) Close() error {
-	if c.db != nil {
-		return c.db.Close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var firstErr error
+	for name, db := range c.dbs {
+		if err := db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.dbs, name)
 	}
-	return nil
+	return firstErr
 }
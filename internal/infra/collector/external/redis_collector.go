@@ -7,26 +7,33 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-var rdb *redis.Client
-
-type RedisCollector struct{ provider domaincfg.Provider }
+// RedisCollector 按实例名称管理多个 Redis 客户端，各实例连接互不影响
+type RedisCollector struct {
+	provider domaincfg.Provider
+	mu       sync.RWMutex
+	clients  map[string]*redis.Client
+}
 
-func NewRedisCollector(p domaincfg.Provider) *RedisCollector { return &RedisCollector{provider: p} }
+func NewRedisCollector(p domaincfg.Provider) *RedisCollector {
+	return &RedisCollector{provider: p, clients: map[string]*redis.Client{}}
+}
 
-func (c *RedisCollector) Init() error {
+// Init 按当前配置（重新）建立指定实例的连接，name 为空表示 redis 单实例
+func (c *RedisCollector) Init(name string) error {
 	cfg := c.provider.GetConfig()
 	if cfg == nil {
 		return fmt.Errorf("配置未加载")
 	}
-    if cfg.AppMonitoring == nil || cfg.AppMonitoring.Redis == nil {
-        return fmt.Errorf("未启用Redis监控")
-    }
-    r := cfg.AppMonitoring.Redis
+	r, ok := cfg.AppMonitoring.RedisTarget(name)
+	if !ok {
+		return fmt.Errorf("未启用Redis监控: %s", entity.TargetLabel("Redis", name))
+	}
 	options := &redis.Options{
         Addr:         r.Addr,
         Password:     r.Password,
@@ -39,7 +46,15 @@ func (c *RedisCollector) Init() error {
         MaxIdleConns: r.MaxIdleConns,
 		PoolTimeout:  2 * time.Second,
 	}
-	rdb = redis.NewClient(options)
+	rdb := redis.NewClient(options)
+	c.mu.Lock()
+	// 重新初始化（如配置热加载）时释放旧连接
+	if old := c.clients[name]; old != nil {
+		old.Close()
+	}
+	c.clients[name] = rdb
+	c.mu.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 	if _, err := rdb.Ping(ctx).Result(); err != nil {
@@ -48,7 +63,22 @@ func (c *RedisCollector) Init() error {
 	return nil
 }
 
-func (c *RedisCollector) GetClients() (int, error) {
+// client 返回指定实例的客户端，未初始化时返回错误
+func (c *RedisCollector) client(name string) (*redis.Client, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	rdb := c.clients[name]
+	if rdb == nil {
+		return nil, fmt.Errorf("%s 未初始化", entity.TargetLabel("Redis", name))
+	}
+	return rdb, nil
+}
+
+func (c *RedisCollector) GetClients(name string) (int, error) {
+	rdb, err := c.client(name)
+	if err != nil {
+		return 0, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 	val, err := rdb.Info(ctx, "clients").Result()
@@ -90,7 +120,11 @@ func (c *RedisCollector) GetClients() (int, error) {
 	return actual, nil
 }
 
-func (c *RedisCollector) GetClientsDetail() ([]entity.ClientInfo, error) {
+func (c *RedisCollector) GetClientsDetail(name string) ([]entity.ClientInfo, error) {
+	rdb, err := c.client(name)
+	if err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 	val, err := rdb.ClientList(ctx).Result()
@@ -136,7 +170,10 @@ func (c *RedisCollector) GetClientsDetail() ([]entity.ClientInfo, error) {
 }
 
 func (c *RedisCollector) Close() {
-	if rdb != nil {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, rdb := range c.clients {
 		rdb.Close()
+		delete(c.clients, name)
 	}
 }
@@ -0,0 +1,115 @@
+package host
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+
+	"github.com/shirou/gopsutil/v3/net"
+)
+
+// ifaceMinRateGap 计算速率的最小采样间隔；多个监控循环几乎同时采集时间隔过短，差值误差会放大速率
+const ifaceMinRateGap = time.Second
+
+// ifaceSample 单个网卡上一次采样的计数器，以及据此计算出的速率
+type ifaceSample struct {
+	counters net.IOCountersStat
+	at       time.Time
+	rates    entity.InterfaceMetrics
+}
+
+var (
+	ifaceMu     sync.Mutex
+	lastIfaceIO = map[string]ifaceSample{}
+)
+
+// GetInterfaceRates 按网卡计算收发字节、包、错误包、丢包速率，按网卡名排序；
+// 首次采样的网卡没有上一次数据，速率为 0；距上次采样不足 ifaceMinRateGap 时沿用上次的速率
+// 计数器在锁内读取，并发采集时后写入的采样总是更新
+func (c *Collector) GetInterfaceRates(cfg *entity.NetworkInterfaceConfig) ([]entity.InterfaceMetrics, error) {
+	if cfg == nil {
+		cfg = &entity.NetworkInterfaceConfig{}
+	}
+
+	ifaceMu.Lock()
+	defer ifaceMu.Unlock()
+
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return nil, fmt.Errorf("获取网卡统计失败: %v", err)
+	}
+	now := time.Now()
+	var result []entity.InterfaceMetrics
+	for _, curr := range counters {
+		if !utils.PatternSelected(cfg.Include, cfg.Exclude, curr.Name) {
+			continue
+		}
+		m := entity.InterfaceMetrics{Name: curr.Name, SpeedMbps: linkSpeed(cfg, curr.Name)}
+		last, ok := lastIfaceIO[curr.Name]
+		fresh := !ok || now.Sub(last.at) >= ifaceMinRateGap
+		if !fresh {
+			m.RecvKBps, m.SentKBps = last.rates.RecvKBps, last.rates.SentKBps
+			m.RecvPps, m.SentPps = last.rates.RecvPps, last.rates.SentPps
+			m.ErrInPs, m.ErrOutPs = last.rates.ErrInPs, last.rates.ErrOutPs
+			m.DropInPs, m.DropOutPs = last.rates.DropInPs, last.rates.DropOutPs
+		} else if ok {
+			if elapsed := now.Sub(last.at).Seconds(); elapsed > 0 {
+				rate := func(curr, prev uint64) float64 { return float64(counterDelta(curr, prev)) / elapsed }
+				m.RecvKBps = rate(curr.BytesRecv, last.counters.BytesRecv) / 1024
+				m.SentKBps = rate(curr.BytesSent, last.counters.BytesSent) / 1024
+				m.RecvPps = rate(curr.PacketsRecv, last.counters.PacketsRecv)
+				m.SentPps = rate(curr.PacketsSent, last.counters.PacketsSent)
+				m.ErrInPs = rate(curr.Errin, last.counters.Errin)
+				m.ErrOutPs = rate(curr.Errout, last.counters.Errout)
+				m.DropInPs = rate(curr.Dropin, last.counters.Dropin)
+				m.DropOutPs = rate(curr.Dropout, last.counters.Dropout)
+			}
+		}
+		if m.SpeedMbps > 0 {
+			// KB/s -> Mbps：*1024*8/1e6
+			peak := m.RecvKBps
+			if m.SentKBps > peak {
+				peak = m.SentKBps
+			}
+			m.BandwidthUsed = peak * 1024 * 8 / 1e6 / float64(m.SpeedMbps) * 100
+		}
+		if fresh {
+			lastIfaceIO[curr.Name] = ifaceSample{counters: curr, at: now, rates: m}
+		}
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// counterDelta 计数器差值；网卡重建或计数器回绕时当前值小于上次值，按 0 处理
+func counterDelta(curr, prev uint64) uint64 {
+	if curr < prev {
+		return 0
+	}
+	return curr - prev
+}
+
+// linkSpeed 网卡链路速率（Mbps）：优先取配置，其次读取 /sys/class/net/<name>/speed，未知时返回 0
+func linkSpeed(cfg *entity.NetworkInterfaceConfig, name string) int {
+	if speed, ok := cfg.Speeds[name]; ok {
+		return speed
+	}
+	data, err := os.ReadFile("/sys/class/net/" + name + "/speed")
+	if err != nil {
+		return 0
+	}
+	speed, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || speed <= 0 {
+		// 虚拟网卡或未连接时内核返回 -1
+		return 0
+	}
+	return speed
+}
@@ -0,0 +1,71 @@
+package host
+
+import (
+	"fmt"
+	"sort"
+
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+
+	"github.com/shirou/gopsutil/v3/disk"
+)
+
+// pseudoFSTypes 默认忽略的伪文件系统/虚拟文件系统，它们不占用真实磁盘或随容器生灭
+var pseudoFSTypes = map[string]bool{
+	"autofs": true, "binfmt_misc": true, "bpf": true, "cgroup": true, "cgroup2": true,
+	"configfs": true, "debugfs": true, "devpts": true, "devtmpfs": true, "efivarfs": true,
+	"fuse.lxcfs": true, "fusectl": true, "hugetlbfs": true, "mqueue": true, "nsfs": true,
+	"overlay": true, "proc": true, "pstore": true, "ramfs": true, "rpc_pipefs": true,
+	"securityfs": true, "squashfs": true, "sysfs": true, "tmpfs": true, "tracefs": true,
+	"aufs": true, "shm": true,
+}
+
+// GetMountUsages 枚举挂载点并统计空间与 inode 使用情况，按挂载点路径排序
+func (c *Collector) GetMountUsages(cfg *entity.DiskMountConfig) ([]entity.MountMetrics, error) {
+	parts, err := disk.Partitions(true)
+	if err != nil {
+		return nil, fmt.Errorf("获取挂载点列表失败: %v", err)
+	}
+	if cfg == nil {
+		cfg = &entity.DiskMountConfig{}
+	}
+
+	seen := map[string]bool{}
+	var mounts []entity.MountMetrics
+	for _, p := range parts {
+		if seen[p.Mountpoint] || !mountSelected(cfg, p) {
+			continue
+		}
+		seen[p.Mountpoint] = true
+
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			// 无权限或已卸载的挂载点直接跳过，不影响其他挂载点
+			continue
+		}
+		mounts = append(mounts, entity.MountMetrics{
+			Mount:         p.Mountpoint,
+			Device:        p.Device,
+			FSType:        p.Fstype,
+			Percent:       usage.UsedPercent,
+			UsedGB:        usage.Used / 1024 / 1024 / 1024,
+			TotalGB:       usage.Total / 1024 / 1024 / 1024,
+			InodesUsed:    usage.InodesUsed,
+			InodesTotal:   usage.InodesTotal,
+			InodesPercent: usage.InodesUsedPercent,
+		})
+	}
+	sort.Slice(mounts, func(i, j int) bool { return mounts[i].Mount < mounts[j].Mount })
+	return mounts, nil
+}
+
+// mountSelected 先按文件系统类型过滤伪文件系统，再按挂载点 include/exclude 模式过滤
+func mountSelected(cfg *entity.DiskMountConfig, p disk.PartitionStat) bool {
+	if utils.MatchAny(cfg.ExcludeFSTypes, p.Fstype) {
+		return false
+	}
+	if pseudoFSTypes[p.Fstype] && !utils.MatchAny(cfg.IncludeFSTypes, p.Fstype) {
+		return false
+	}
+	return utils.PatternSelected(cfg.Include, cfg.Exclude, p.Mountpoint)
+}
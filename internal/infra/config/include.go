@@ -0,0 +1,142 @@
+package configimpl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeDirKey 基础配置中指定 include 目录的键
+const includeDirKey = "include_dir"
+
+// loadMergedNode 读取基础配置文件，并按文件名顺序合并 include 目录下的所有 *.yml/*.yaml
+// 返回合并后的文档节点和 include 目录（未配置时为空）
+func loadMergedNode(path string) (*yaml.Node, string, error) {
+	root, err := readYAMLNode(path)
+	if err != nil {
+		return nil, "", err
+	}
+	dir, err := includeDir(path, root)
+	if err != nil || dir == "" {
+		return root, dir, err
+	}
+	files, err := includeFiles(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, f := range files {
+		n, err := readYAMLNode(f)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(n.Content) == 0 {
+			continue
+		}
+		if len(root.Content) == 0 {
+			root = n
+			continue
+		}
+		if err := mergeNodes(root.Content[0], n.Content[0], ""); err != nil {
+			return nil, "", fmt.Errorf("合并 %s 失败: %v", f, err)
+		}
+	}
+	return root, dir, nil
+}
+
+// readYAMLNode 读取并解析单个 YAML 文件为节点树
+func readYAMLNode(path string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %v", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %v", path, err)
+	}
+	return &root, nil
+}
+
+// includeDir 从基础配置中取出 include_dir（支持变量引用），相对路径以基础配置文件所在目录为准
+func includeDir(basePath string, root *yaml.Node) (string, error) {
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return "", nil
+	}
+	m := root.Content[0]
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != includeDirKey {
+			continue
+		}
+		dir, err := expandString(m.Content[i+1].Value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %v", includeDirKey, err)
+		}
+		dir = strings.TrimSpace(dir)
+		if dir != "" && !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(basePath), dir)
+		}
+		return dir, nil
+	}
+	return "", nil
+}
+
+// includeFiles 列出 include 目录下的 YAML 文件，按文件名排序；目录不存在时返回空
+func includeFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取 include 目录失败: %v", err)
+	}
+	var files []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yml" && ext != ".yaml") || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// mergeNodes 将 src 合并进 dst：映射按键深度合并，列表追加，标量由 src 覆盖，空值忽略
+func mergeNodes(dst, src *yaml.Node, path string) error {
+	switch {
+	case src.Kind == yaml.ScalarNode && src.Tag == "!!null":
+		// 空值不覆盖已有配置
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, val := src.Content[i], src.Content[i+1]
+			if existing := mappingValue(dst, key.Value); existing != nil {
+				if err := mergeNodes(existing, val, joinPath(path, key.Value)); err != nil {
+					return err
+				}
+				continue
+			}
+			dst.Content = append(dst.Content, key, val)
+		}
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode:
+		dst.Content = append(dst.Content, src.Content...)
+	case dst.Kind == yaml.ScalarNode && src.Kind == yaml.ScalarNode,
+		dst.Kind == yaml.ScalarNode && dst.Tag == "!!null":
+		*dst = *src
+	default:
+		return fmt.Errorf("%s: 类型不一致，无法合并", path)
+	}
+	return nil
+}
+
+// mappingValue 返回映射节点中指定键的值节点
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package configimpl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 支持的引用形式：
+//   ${ENV_VAR}            读取环境变量，未设置时报错
+//   ${ENV_VAR:-default}   环境变量未设置或为空时使用默认值
+//   ${file:/path/to/x}    读取文件内容（去除末尾换行），适用于 Docker/K8s secret
+//   $${...}               转义，输出字面量 ${...}
+
+// interpolateNode 递归展开 YAML 节点树中所有标量值里的引用
+// 只处理值不处理键；path 用于错误信息中定位配置项
+func interpolateNode(n *yaml.Node, path string) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			if err := interpolateNode(c, path); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if err := interpolateNode(n.Content[i+1], joinPath(path, n.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			if err := interpolateNode(c, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(n.Value, "$") {
+			return nil
+		}
+		v, err := expandString(n.Value)
+		if err != nil {
+			return fmt.Errorf("%s (第 %d 行): %v", path, n.Line, err)
+		}
+		if v != n.Value {
+			n.Value = v
+			// 未加引号的值按展开后的内容重新推断类型，使 ${PORT} 等可以用于数字/布尔字段
+			if n.Style == 0 {
+				n.Tag = ""
+			}
+		}
+	}
+	return nil
+}
+
+// expandString 展开字符串中的全部 ${...} 引用
+func expandString(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteByte('$')
+			i += 2
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		end := strings.IndexByte(s[i+2:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("引用缺少右括号: %s", s[i:])
+		}
+		v, err := resolveRef(s[i+2 : i+2+end])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(v)
+		i += end + 3
+	}
+	return b.String(), nil
+}
+
+// resolveRef 解析单个引用表达式（不含 ${ 和 }）
+func resolveRef(ref string) (string, error) {
+	if strings.HasPrefix(ref, "file:") {
+		path := strings.TrimSpace(strings.TrimPrefix(ref, "file:"))
+		if path == "" {
+			return "", fmt.Errorf("引用 ${%s} 缺少文件路径", ref)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("读取引用文件 %s 失败: %v", path, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	name, def, hasDefault := strings.Cut(ref, ":-")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("引用 ${%s} 缺少变量名", ref)
+	}
+	if v, ok := os.LookupEnv(name); ok && (v != "" || !hasDefault) {
+		return v, nil
+	}
+	if hasDefault {
+		return def, nil
+	}
+	return "", fmt.Errorf("环境变量 %s 未设置", name)
+}
+
+// joinPath 拼接 YAML 路径
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
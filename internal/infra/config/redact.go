@@ -0,0 +1,65 @@
+package configimpl
+
+import (
+	"GWatch/internal/entity"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue 脱敏后的占位值
+const redactedValue = "******"
+
+// sensitiveKeys 键名包含这些片段的配置值视为敏感信息
+var sensitiveKeys = []string{"password", "secret", "token", "webhook", "backdoor_code", "authorization"}
+
+// notSensitiveKeys 虽然命中片段但并非敏感信息的键
+var notSensitiveKeys = map[string]bool{"token_cache_duration": true}
+
+// RedactedYAML 将生效的配置序列化为 YAML，并对密码、密钥、token、webhook 等敏感值脱敏
+func RedactedYAML(c *entity.Config) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(c); err != nil {
+		return nil, fmt.Errorf("序列化配置失败: %v", err)
+	}
+	redactNode(&root, "")
+	out, err := yaml.Marshal(&root)
+	if err != nil {
+		return nil, fmt.Errorf("序列化配置失败: %v", err)
+	}
+	return out, nil
+}
+
+// redactNode 递归替换敏感键对应的非空值
+func redactNode(n *yaml.Node, key string) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			redactNode(n.Content[i+1], n.Content[i].Value)
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			redactNode(c, key)
+		}
+	case yaml.ScalarNode:
+		if n.Value != "" && isSensitiveKey(key) {
+			n.Value = redactedValue
+			n.Tag = "!!str"
+			n.Style = 0
+		}
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	if notSensitiveKeys[k] {
+		return false
+	}
+	for _, s := range sensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
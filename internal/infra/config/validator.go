@@ -0,0 +1,885 @@
+package configimpl
+
+import (
+	"GWatch/internal/entity"
+	"GWatch/internal/infra/monitoring/rules"
+	"GWatch/internal/infra/monitoring/templates"
+	"GWatch/internal/utils"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 缺省值（配置项为 0 或空时自动填充）
+const (
+	DefaultHostInterval         = 5 * time.Second
+	DefaultConsecutiveThreshold = 3
+	DefaultAlertInterval        = 2 * time.Minute
+	DefaultRecoveryThreshold    = 3
+	DefaultUsageThreshold       = 80.0 // cpu/memory/disk 阈值（%）
+	DefaultInodeThreshold       = 90.0 // 挂载点 inode 阈值（%）
+	DefaultNetworkExclude       = "lo" // 未配置网卡过滤时排除回环网卡
+	DefaultRedisTimeout         = 5 * time.Second
+	DefaultMySQLPort            = 3306
+	DefaultMySQLTimeout         = 10 * time.Second
+	DefaultMySQLInterval        = 60 * time.Second
+	DefaultHTTPInterval         = 10 * time.Second
+	DefaultHTTPTimeout          = 10 * time.Second
+	DefaultScheduledPushMode    = "client"
+	DefaultAggregationDelay     = 60 // 秒
+	DefaultAlertStorageFormat   = "text"
+	DefaultSMTPPort             = 587
+	DefaultSMTPTLSPort          = 465 // 该端口默认直接使用 TLS 连接
+	DefaultOutboxDir            = "data/outbox"
+	DefaultOutboxMaxAttempts    = 10
+	DefaultOutboxInitialBackoff = 10 * time.Second
+	DefaultOutboxMaxBackoff     = 10 * time.Minute
+	DefaultOutboxDedupWindow    = 5 * time.Minute
+	DefaultSilencesFile         = "data/silences.json"
+	DefaultPolicyStateFile      = "data/policy_state.json"
+	DefaultPolicyStateInterval  = 30 * time.Second
+	DefaultPolicyStateMaxAge    = time.Hour
+	DefaultHistoryFile          = "data/history.db"
+	DefaultHistoryRetention     = 30 * 24 * time.Hour
+	DefaultFlappingWindow       = 10 * time.Minute
+	DefaultFlappingThreshold    = 6
+	DefaultFlappingStableFor    = 10 * time.Minute
+	DefaultAnomalySigma         = 3.0
+	DefaultAnomalyFor           = 10 * time.Minute
+	DefaultAnomalyAlpha         = 0.2
+	DefaultAnomalyMinPeriods    = 3
+	DefaultAnomalyFile          = "data/baselines.json"
+	DefaultAnomalySaveInterval  = 5 * time.Minute
+	DefaultDiskForecastWindow   = 6 * time.Hour
+	DefaultDiskForecastMinSpan  = time.Hour
+	DefaultDiskForecastHorizon  = 24 * time.Hour
+	DefaultLogMode              = "console"
+	DefaultLogLevel             = "info"
+)
+
+// ValidationError 单个配置问题，Path 为 YAML 路径，如 app_monitoring.http.interfaces[1].timeout
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors 一次校验发现的全部问题
+type ValidationErrors []ValidationError
+
+func (es ValidationErrors) Error() string {
+	lines := make([]string, 0, len(es)+1)
+	lines = append(lines, fmt.Sprintf("发现 %d 处配置错误:", len(es)))
+	for _, e := range es {
+		lines = append(lines, "  - "+e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate 为缺省项填充默认值、规范化时间格式，并校验整个配置
+// 返回 nil 或 ValidationErrors（包含所有问题，而不是遇到第一个就停止）
+func Validate(c *entity.Config) error {
+	v := &validator{}
+	v.hostMonitoring(c.HostMonitoring)
+	v.appMonitoring(c.AppMonitoring)
+	v.scheduledPush(c.ScheduledPush)
+	v.notifiers(c)
+	v.routing(c)
+	v.alertPolicies(c.AlertPolicies)
+	v.inhibitRules(c.InhibitRules)
+	v.escalation(c)
+	v.flapping(c.Flapping)
+	v.anomaly(c.Anomaly)
+	v.rules(&c.Rules)
+	v.outbox(&c.Outbox)
+	v.policyState(&c.PolicyState)
+	v.history(&c.History)
+	v.templates(&c.Templates)
+	v.silences(&c.Silences)
+	v.log(&c.Log)
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// NormalizeClock 将 "8:00"、"08:00"、" 8:0 " 等统一为调度器使用的 "8:00" 格式
+func NormalizeClock(s string) (string, error) {
+	hs, ms, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return "", fmt.Errorf("时间格式应为 H:MM，当前为 %q", s)
+	}
+	h, err1 := strconv.Atoi(hs)
+	m, err2 := strconv.Atoi(ms)
+	if err1 != nil || err2 != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return "", fmt.Errorf("无效的时间 %q（小时 0-23，分钟 0-59）", s)
+	}
+	return fmt.Sprintf("%d:%02d", h, m), nil
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// duration 负数报错，0 填充默认值
+func (v *validator) duration(path string, d *time.Duration, def time.Duration) {
+	if *d < 0 {
+		v.add(path, "不能为负数")
+	} else if *d == 0 {
+		*d = def
+	}
+}
+
+// percent 阈值须在 (0, 100]，0 填充默认值
+func (v *validator) percent(path string, p *float64, def float64) {
+	if *p == 0 {
+		*p = def
+	} else if *p < 0 || *p > 100 {
+		v.add(path, "应在 0-100 之间，当前为 %v", *p)
+	}
+}
+
+func (v *validator) required(path, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "不能为空")
+		return false
+	}
+	return true
+}
+
+func (v *validator) httpURL(path, value string) {
+	if !v.required(path, value) {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(path, "不是有效的 http/https 地址: %q", value)
+	}
+}
+
+// clocks 规范化时间点列表，空列表或非法时间报错
+func (v *validator) clocks(path string, times []string) {
+	if len(times) == 0 {
+		v.add(path, "至少需要配置一个时间点")
+		return
+	}
+	seen := map[string]bool{}
+	for i, t := range times {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		n, err := NormalizeClock(t)
+		if err != nil {
+			v.add(p, "%v", err)
+			continue
+		}
+		if seen[n] {
+			v.add(p, "时间点 %s 重复", n)
+		}
+		seen[n] = true
+		times[i] = n
+	}
+}
+
+func (v *validator) oneOf(path, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(path, "仅支持 %s，当前为 %q", strings.Join(allowed, "/"), value)
+}
+
+// critical critical 阈值可选（0 表示不区分级别），配置时须大于对应的告警阈值；percent 为 true 时还须在 0-100 之间
+func (v *validator) critical(path string, value, warning float64, warningName string, percent bool) {
+	switch {
+	case value == 0:
+	case value < 0 || (percent && value > 100):
+		v.add(path, "应在 0-100 之间，当前为 %v", value)
+	case value <= warning:
+		v.add(path, "应大于 %s (%v)，当前为 %v", warningName, warning, value)
+	}
+}
+
+func (v *validator) hostMonitoring(h *entity.HostMonitoringConfig) {
+	if h == nil {
+		return
+	}
+	const p = "host_monitoring"
+	v.duration(p+".interval", &h.Interval, DefaultHostInterval)
+	v.duration(p+".alert_interval", &h.AlertInterval, DefaultAlertInterval)
+	if h.ConsecutiveThreshold < 0 {
+		v.add(p+".consecutive_threshold", "不能为负数")
+	} else if h.ConsecutiveThreshold == 0 {
+		h.ConsecutiveThreshold = DefaultConsecutiveThreshold
+	}
+	if h.RecoveryThreshold < 0 {
+		v.add(p+".recovery_threshold", "不能为负数")
+	} else if h.RecoveryThreshold == 0 {
+		h.RecoveryThreshold = DefaultRecoveryThreshold
+	}
+	v.percent(p+".cpu_threshold", &h.CPUThreshold, DefaultUsageThreshold)
+	v.percent(p+".memory_threshold", &h.MemoryThreshold, DefaultUsageThreshold)
+	v.percent(p+".disk_threshold", &h.DiskThreshold, DefaultUsageThreshold)
+	v.critical(p+".cpu_critical_threshold", h.CPUCriticalThreshold, h.CPUThreshold, "cpu_threshold", true)
+	v.critical(p+".memory_critical_threshold", h.MemoryCriticalThreshold, h.MemoryThreshold, "memory_threshold", true)
+	v.critical(p+".disk_critical_threshold", h.DiskCriticalThreshold, h.DiskThreshold, "disk_threshold", true)
+	v.disks(p+".disks", h)
+	v.network(p+".network", h)
+}
+
+// network 网卡过滤与阈值，未配置时默认排除回环网卡 lo
+func (v *validator) network(p string, h *entity.HostMonitoringConfig) {
+	if h.Network == nil {
+		h.Network = &entity.NetworkInterfaceConfig{}
+	}
+	n := h.Network
+	if n.Include == nil && n.Exclude == nil {
+		n.Exclude = []string{DefaultNetworkExclude}
+	}
+	v.patterns(p+".include", n.Include)
+	v.patterns(p+".exclude", n.Exclude)
+	if n.BandwidthThreshold < 0 || n.BandwidthThreshold > 100 {
+		v.add(p+".bandwidth_threshold", "应在 0-100 之间，当前为 %v", n.BandwidthThreshold)
+	}
+	v.critical(p+".bandwidth_critical_threshold", n.BandwidthCriticalThreshold, n.BandwidthThreshold, "bandwidth_threshold", true)
+	if n.ErrorRateThreshold < 0 {
+		v.add(p+".error_rate_threshold", "不能为负数")
+	}
+	if n.DropRateThreshold < 0 {
+		v.add(p+".drop_rate_threshold", "不能为负数")
+	}
+	for name, speed := range n.Speeds {
+		if speed <= 0 {
+			v.add(fmt.Sprintf("%s.speeds[%s]", p, name), "链路速率必须大于 0，当前为 %d", speed)
+		}
+	}
+}
+
+// disks 挂载点过滤与阈值，未配置时按缺省值监控所有真实文件系统
+func (v *validator) disks(p string, h *entity.HostMonitoringConfig) {
+	if h.Disks == nil {
+		h.Disks = &entity.DiskMountConfig{}
+	}
+	d := h.Disks
+	v.patterns(p+".include", d.Include)
+	v.patterns(p+".exclude", d.Exclude)
+	v.percent(p+".inode_threshold", &d.InodeThreshold, DefaultInodeThreshold)
+	v.critical(p+".inode_critical_threshold", d.InodeCriticalThreshold, d.InodeThreshold, "inode_threshold", true)
+	for mount, threshold := range d.Thresholds {
+		if threshold <= 0 || threshold > 100 {
+			v.add(fmt.Sprintf("%s.thresholds[%s]", p, mount), "应在 0-100 之间，当前为 %v", threshold)
+		}
+	}
+	for mount, threshold := range d.CriticalThresholds {
+		mp := fmt.Sprintf("%s.critical_thresholds[%s]", p, mount)
+		if threshold <= 0 {
+			v.add(mp, "应在 0-100 之间，当前为 %v", threshold)
+			continue
+		}
+		v.critical(mp, threshold, h.MountThreshold(mount), "该挂载点的告警阈值", true)
+	}
+	v.diskForecast(p+".forecast", d.Forecast)
+}
+
+// diskForecast 写满预测：min_span 不能超过 window，critical_horizon 须短于 horizon
+func (v *validator) diskForecast(p string, f *entity.DiskForecastConfig) {
+	if f == nil {
+		return
+	}
+	v.duration(p+".window", &f.Window, DefaultDiskForecastWindow)
+	v.duration(p+".min_span", &f.MinSpan, DefaultDiskForecastMinSpan)
+	v.duration(p+".horizon", &f.Horizon, DefaultDiskForecastHorizon)
+	if f.MinSpan > f.Window {
+		v.add(p+".min_span", "不能大于 window (%v)", f.Window)
+	}
+	if f.CriticalHorizon < 0 {
+		v.add(p+".critical_horizon", "不能为负数")
+	} else if f.CriticalHorizon >= f.Horizon {
+		v.add(p+".critical_horizon", "应短于 horizon (%v)", f.Horizon)
+	}
+}
+
+// patterns 校验通配符模式（path.Match 语法）
+func (v *validator) patterns(p string, patterns []string) {
+	for i, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			v.add(fmt.Sprintf("%s[%d]", p, i), "通配符模式无效: %q", pattern)
+		}
+	}
+}
+
+func (v *validator) appMonitoring(a *entity.AppMonitoringConfig) {
+	if a == nil || !a.Enabled {
+		return
+	}
+	const p = "app_monitoring"
+	v.redis(p+".redis", a.Redis)
+	v.redisInstances(p+".redis_instances", a)
+	v.mysql(p+".mysql", a.MySQL)
+	v.mysqlInstances(p+".mysql_instances", a)
+	v.http(p+".http", a.HTTP)
+	v.tickers(p+".tickers", a.Tickers)
+}
+
+func (v *validator) redis(p string, r *entity.RedisConfig) {
+	if r == nil || !r.Enabled {
+		return
+	}
+	v.required(p+".addr", r.Addr)
+	v.duration(p+".timeout", &r.Timeout, DefaultRedisTimeout)
+	if r.DB < 0 {
+		v.add(p+".db", "不能为负数")
+	}
+	if r.PoolSize < 0 {
+		v.add(p+".pool_size", "不能为负数")
+	}
+	if r.MinClients < 0 {
+		v.add(p+".min_clients", "不能为负数")
+	}
+	if r.MaxClients > 0 && r.MinClients > r.MaxClients {
+		v.add(p+".min_clients", "不能大于 max_clients (%d)", r.MaxClients)
+	}
+	v.critical(p+".max_clients_critical", float64(r.MaxClientsCritical), float64(r.MaxClients), "max_clients", false)
+}
+
+// redisInstances 校验多实例 Redis：名称必填且不能与其他实例（包括 redis 单实例）重名
+func (v *validator) redisInstances(p string, a *entity.AppMonitoringConfig) {
+	names := map[string]string{}
+	if a.Redis != nil && a.Redis.Name != "" {
+		names[a.Redis.Name] = "redis"
+	}
+	for i := range a.RedisInstances {
+		ip := fmt.Sprintf("%s[%d]", p, i)
+		r := &a.RedisInstances[i]
+		if v.required(ip+".name", r.Name) {
+			if other, ok := names[r.Name]; ok {
+				v.add(ip+".name", "与 %s 重名: %q", other, r.Name)
+			}
+			names[r.Name] = fmt.Sprintf("redis_instances[%d]", i)
+		}
+		v.redis(ip, r)
+	}
+}
+
+func (v *validator) mysql(p string, m *entity.MySQLMonitoringConfig) {
+	if m == nil || !m.Enabled {
+		return
+	}
+	v.required(p+".host", m.Host)
+	v.required(p+".username", m.Username)
+	if m.Port == 0 {
+		m.Port = DefaultMySQLPort
+	} else if m.Port < 0 || m.Port > 65535 {
+		v.add(p+".port", "无效的端口 %d", m.Port)
+	}
+	if m.Role != "" {
+		v.oneOf(p+".role", m.Role, "primary", "replica")
+	}
+	v.duration(p+".timeout", &m.Timeout, DefaultMySQLTimeout)
+	v.duration(p+".interval", &m.Interval, DefaultMySQLInterval)
+	t := &m.Thresholds
+	if t.MaxConnectionsUsageWarning < 0 || t.MaxConnectionsUsageWarning > 100 {
+		v.add(p+".thresholds.max_connections_usage_warning", "应在 0-100 之间")
+	}
+	if t.BufferPoolHitRateWarning < 0 || t.BufferPoolHitRateWarning > 100 {
+		v.add(p+".thresholds.buffer_pool_hit_rate_warning", "应在 0-100 之间")
+	}
+	v.critical(p+".thresholds.max_connections_usage_critical", t.MaxConnectionsUsageCritical, t.MaxConnectionsUsageWarning, "max_connections_usage_warning", true)
+	v.critical(p+".thresholds.threads_running_critical", float64(t.ThreadsRunningCritical), float64(t.ThreadsRunningWarning), "threads_running_warning", false)
+	if t.QPSWarning < 0 {
+		v.add(p+".thresholds.qps_warning", "不能为负数")
+	}
+	if t.QPSCritical != 0 && t.QPSWarning == 0 {
+		v.add(p+".thresholds.qps_critical", "需要同时配置 qps_warning")
+	}
+	v.critical(p+".thresholds.qps_critical", float64(t.QPSCritical), float64(t.QPSWarning), "qps_warning", false)
+	v.critical(p+".thresholds.replication_delay_critical_seconds", float64(t.ReplicationDelayCriticalSeconds), float64(m.ReplicationDelayThreshold()), "复制延迟告警阈值", false)
+	if m.Replication != nil && m.Replication.DelayWarningSeconds < 0 {
+		v.add(p+".replication.delay_warning_seconds", "不能为负数")
+	}
+}
+
+// mysqlInstances 校验多实例 MySQL：名称必填且不能与其他实例（包括 mysql 单实例）重名
+func (v *validator) mysqlInstances(p string, a *entity.AppMonitoringConfig) {
+	names := map[string]string{}
+	if a.MySQL != nil && a.MySQL.Name != "" {
+		names[a.MySQL.Name] = "mysql"
+	}
+	for i := range a.MySQLInstances {
+		ip := fmt.Sprintf("%s[%d]", p, i)
+		m := &a.MySQLInstances[i]
+		if v.required(ip+".name", m.Name) {
+			if other, ok := names[m.Name]; ok {
+				v.add(ip+".name", "与 %s 重名: %q", other, m.Name)
+			}
+			names[m.Name] = fmt.Sprintf("mysql_instances[%d]", i)
+		}
+		v.mysql(ip, m)
+	}
+}
+
+func (v *validator) http(p string, h *entity.HTTPMonitoringConfig) {
+	if h == nil || !h.Enabled {
+		return
+	}
+	v.duration(p+".interval", &h.Interval, DefaultHTTPInterval)
+	if h.ErrorThreshold < 0 {
+		v.add(p+".error_threshold", "不能为负数")
+	}
+	v.critical(p+".critical_error_threshold", float64(h.CriticalErrorThreshold), float64(h.ErrorThreshold), "error_threshold", false)
+	names := map[string]int{}
+	for i := range h.Interfaces {
+		ip := fmt.Sprintf("%s.interfaces[%d]", p, i)
+		itf := &h.Interfaces[i]
+		if v.required(ip+".name", itf.Name) {
+			if j, ok := names[itf.Name]; ok {
+				v.add(ip+".name", "与 interfaces[%d] 重名: %q", j, itf.Name)
+			}
+			names[itf.Name] = i
+		}
+		v.httpURL(ip+".url", itf.URL)
+		v.duration(ip+".timeout", &itf.Timeout, DefaultHTTPTimeout)
+		for j, code := range itf.AllowedCodes {
+			if code < 100 || code > 599 {
+				v.add(fmt.Sprintf("%s.allowed_codes[%d]", ip, j), "无效的 HTTP 状态码 %d", code)
+			}
+		}
+	}
+}
+
+func (v *validator) tickers(p string, t *entity.TickersConfig) {
+	if t == nil || !t.Enabled {
+		return
+	}
+	for i := range t.TickerInterfaces {
+		ip := fmt.Sprintf("%s.ticker_interfaces[%d]", p, i)
+		ti := &t.TickerInterfaces[i]
+		v.required(ip+".name", ti.Name)
+		v.httpURL(ip+".device_url", ti.DeviceURL)
+		v.clocks(ip+".alert_time", ti.AlertTime)
+		v.auth(ip+".auth", &ti.Auth)
+	}
+}
+
+func (v *validator) auth(p string, a *entity.AuthConfig) {
+	switch a.Mode {
+	case "static":
+		v.required(p+".static_token", a.StaticToken)
+	case "dynamic":
+		v.httpURL(p+".login_url", a.LoginURL)
+		v.required(p+".username", a.Username)
+		v.required(p+".password", a.Password)
+	default:
+		v.oneOf(p+".mode", a.Mode, "static", "dynamic")
+	}
+	if a.TokenCacheDuration != "" {
+		if d, err := time.ParseDuration(a.TokenCacheDuration); err != nil || d <= 0 {
+			v.add(p+".token_cache_duration", "无效的时长 %q（示例: 30m、1h）", a.TokenCacheDuration)
+		}
+	}
+}
+
+func (v *validator) scheduledPush(s *entity.ScheduledPushConfig) {
+	if s == nil || !s.Enabled {
+		return
+	}
+	const p = "scheduled_push"
+	if s.Mode == "" {
+		s.Mode = DefaultScheduledPushMode
+	}
+	v.oneOf(p+".mode", s.Mode, "client", "server")
+	v.required(p+".rds_url", s.RdsURL)
+	if s.RdsDB < 0 {
+		v.add(p+".rds_db", "不能为负数")
+	}
+	v.clocks(p+".push_times", s.PushTimes)
+	if s.ServerAggregationDelaySeconds < 0 {
+		v.add(p+".server_aggregation_delay_seconds", "不能为负数")
+	} else if s.ServerAggregationDelaySeconds == 0 {
+		s.ServerAggregationDelaySeconds = DefaultAggregationDelay
+	}
+	if st := s.AlertStorage; st != nil && st.Enabled {
+		v.required(p+".alert_storage.alert_log_path_template", st.AlertLogPathTemplate)
+		if st.Format == "" {
+			st.Format = DefaultAlertStorageFormat
+		}
+		v.oneOf(p+".alert_storage.format", st.Format, "json", "text")
+		if st.RetentionDays < 0 {
+			v.add(p+".alert_storage.retention_days", "不能为负数（0 表示永久保留）")
+		}
+	}
+}
+
+// notifiers 校验 dingtalk 与 notifiers 通知渠道，至少需要一个生效的渠道
+func (v *validator) notifiers(c *entity.Config) {
+	names := map[string]string{}
+	if c.DingTalk.WebhookURL != "" {
+		v.httpURL("dingtalk.webhook_url", c.DingTalk.WebhookURL)
+		names[entity.NotifierDingTalk] = "dingtalk"
+	}
+	enabled := 0
+	for i := range c.Notifiers {
+		p := fmt.Sprintf("notifiers[%d]", i)
+		n := &c.Notifiers[i]
+		if v.required(p+".name", n.Name) {
+			if other, ok := names[n.Name]; ok {
+				v.add(p+".name", "与 %s 重名: %q", other, n.Name)
+			}
+			names[n.Name] = p
+		}
+		v.oneOf(p+".type", n.Type, entity.NotifierDingTalk, entity.NotifierEmail, entity.NotifierWeCom,
+			entity.NotifierFeishu, entity.NotifierSlack, entity.NotifierWebhook)
+		if n.RateLimitPerMinute < 0 {
+			v.add(p+".rate_limit_per_minute", "不能为负数")
+		} else if n.RateLimitPerMinute == 0 && n.Type == entity.NotifierDingTalk {
+			n.RateLimitPerMinute = entity.DingTalkRateLimitPerMinute
+		}
+		if !n.Enabled {
+			continue
+		}
+		enabled++
+		v.notifier(p, n)
+	}
+	if c.DingTalk.WebhookURL == "" && enabled == 0 {
+		v.add("dingtalk.webhook_url", "未配置任何通知渠道（dingtalk 或已启用的 notifiers）")
+	}
+}
+
+// knownChannels 已在 dingtalk/notifiers 中配置的渠道名称
+func knownChannels(c *entity.Config) map[string]bool {
+	known := map[string]bool{}
+	if c.DingTalk.WebhookURL != "" {
+		known[entity.NotifierDingTalk] = true
+	}
+	for _, n := range c.Notifiers {
+		known[n.Name] = true
+	}
+	return known
+}
+
+// routing 校验告警路由：渠道名称必须已在 dingtalk/notifiers 中配置，匹配模式必须合法
+func (v *validator) routing(c *entity.Config) {
+	if c.Routing == nil {
+		return
+	}
+	known := knownChannels(c)
+	route := func(p string, r *entity.AlertRoute) {
+		for i, name := range r.Channels {
+			if !known[name] {
+				v.add(fmt.Sprintf("%s.channels[%d]", p, i), "未配置的通知渠道 %q", name)
+			}
+		}
+		v.patterns(p+".alert_types", r.AlertTypes)
+		v.patterns(p+".hosts", r.Hosts)
+		v.patterns(p+".targets", r.Targets)
+		for i, s := range r.Severities {
+			v.oneOf(fmt.Sprintf("%s.severities[%d]", p, i), s, entity.SeverityInfo, entity.SeverityWarning, entity.SeverityCritical)
+		}
+	}
+	for i := range c.Routing.Routes {
+		route(fmt.Sprintf("routing.routes[%d]", i), &c.Routing.Routes[i])
+	}
+	route("routing.default", &c.Routing.Default)
+}
+
+// alertPolicies 校验按告警类型的策略：匹配模式合法且告警类型模式至少匹配一种类型，各项不能为负数且至少设置一项
+func (v *validator) alertPolicies(policies []entity.AlertPolicy) {
+	for i := range policies {
+		ap := &policies[i]
+		p := fmt.Sprintf("alert_policies[%d]", i)
+		v.alertTypes(p+".alert_types", ap.AlertTypes)
+		v.patterns(p+".targets", ap.Targets)
+		if ap.ConsecutiveThreshold < 0 {
+			v.add(p+".consecutive_threshold", "不能为负数")
+		}
+		if ap.RepeatInterval < 0 {
+			v.add(p+".repeat_interval", "不能为负数")
+		}
+		if ap.RecoveryThreshold < 0 {
+			v.add(p+".recovery_threshold", "不能为负数")
+		}
+		if ap.ConsecutiveThreshold == 0 && ap.RepeatInterval == 0 && ap.RecoveryThreshold == 0 {
+			v.add(p, "consecutive_threshold、repeat_interval、recovery_threshold 至少设置一项")
+		}
+	}
+}
+
+// alertTypes 告警类型匹配模式合法，且每个模式至少匹配一种告警类型
+func (v *validator) alertTypes(p string, patterns []string) {
+	v.patterns(p, patterns)
+	for i, pattern := range patterns {
+		matched := false
+		for t := range entity.AlertTypeText {
+			if ok, _ := path.Match(pattern, string(t)); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.add(fmt.Sprintf("%s[%d]", p, i), "未匹配任何告警类型: %q", pattern)
+		}
+	}
+}
+
+// inhibitRules 校验告警抑制规则：名称唯一，源/目标告警类型必填且能匹配告警类型，equal 默认 target
+func (v *validator) inhibitRules(rules []entity.InhibitRule) {
+	names := map[string]bool{}
+	for i := range rules {
+		r := &rules[i]
+		p := fmt.Sprintf("inhibit_rules[%d]", i)
+		if v.required(p+".name", r.Name) {
+			if names[r.Name] {
+				v.add(p+".name", "名称 %q 重复", r.Name)
+			}
+			names[r.Name] = true
+		}
+		if len(r.SourceTypes) == 0 {
+			v.add(p+".source_types", "不能为空")
+		}
+		if len(r.TargetTypes) == 0 {
+			v.add(p+".target_types", "不能为空")
+		}
+		v.alertTypes(p+".source_types", r.SourceTypes)
+		v.alertTypes(p+".target_types", r.TargetTypes)
+		if r.Equal == "" {
+			r.Equal = entity.InhibitEqualTarget
+		}
+		v.oneOf(p+".equal", r.Equal, entity.InhibitEqualTarget, entity.InhibitEqualHost)
+	}
+}
+
+// escalation 校验告警升级：after 必须大于 0，至少配置额外的渠道或 @ 列表之一
+func (v *validator) escalation(c *entity.Config) {
+	e := c.Escalation
+	if e == nil {
+		return
+	}
+	const p = "escalation"
+	if e.After <= 0 {
+		v.add(p+".after", "必须大于 0")
+	}
+	known := knownChannels(c)
+	for i, name := range e.Channels {
+		if !known[name] {
+			v.add(fmt.Sprintf("%s.channels[%d]", p, i), "未配置的通知渠道 %q", name)
+		}
+	}
+	if len(e.Channels) == 0 && len(e.AtMobiles) == 0 {
+		v.add(p, "channels 与 at_mobiles 至少配置一项")
+	}
+}
+
+// flapping 抖动检测：threshold 至少为 2（一次切换只是正常的告警或恢复）
+func (v *validator) flapping(f *entity.FlappingConfig) {
+	if f == nil {
+		return
+	}
+	v.duration("flapping.window", &f.Window, DefaultFlappingWindow)
+	v.duration("flapping.stable_for", &f.StableFor, DefaultFlappingStableFor)
+	if f.Threshold == 0 {
+		f.Threshold = DefaultFlappingThreshold
+	} else if f.Threshold < 2 {
+		v.add("flapping.threshold", "至少为 2，当前为 %d", f.Threshold)
+	}
+}
+
+// anomaly 异常检测：指标名称须为支持的指标，critical_sigma 须大于 sigma
+func (v *validator) anomaly(a *entity.AnomalyConfig) {
+	if a == nil {
+		return
+	}
+	const p = "anomaly"
+	for i, m := range a.Metrics {
+		v.oneOf(fmt.Sprintf("%s.metrics[%d]", p, i), m, entity.AnomalyMetrics...)
+	}
+	if a.Sigma < 0 {
+		v.add(p+".sigma", "不能为负数")
+	} else if a.Sigma == 0 {
+		a.Sigma = DefaultAnomalySigma
+	}
+	v.critical(p+".critical_sigma", a.CriticalSigma, a.Sigma, "sigma", false)
+	v.duration(p+".for", &a.For, DefaultAnomalyFor)
+	if a.Alpha < 0 || a.Alpha > 1 {
+		v.add(p+".alpha", "应在 0-1 之间，当前为 %v", a.Alpha)
+	} else if a.Alpha == 0 {
+		a.Alpha = DefaultAnomalyAlpha
+	}
+	if a.MinPeriods < 0 {
+		v.add(p+".min_periods", "不能为负数")
+	} else if a.MinPeriods == 0 {
+		a.MinPeriods = DefaultAnomalyMinPeriods
+	}
+	if a.File == "" {
+		a.File = DefaultAnomalyFile
+	}
+	v.duration(p+".save_interval", &a.SaveInterval, DefaultAnomalySaveInterval)
+}
+
+// rules 校验自定义规则：名称唯一，表达式通过类型检查且结果为 bool，消息模板可解析
+func (v *validator) rules(r *entity.RulesConfig) {
+	names := map[string]bool{}
+	for i := range r.Alerts {
+		ar := &r.Alerts[i]
+		p := fmt.Sprintf("rules.alerts[%d]", i)
+		if v.required(p+".name", ar.Name) {
+			if names[ar.Name] {
+				v.add(p+".name", "名称 %q 重复", ar.Name)
+			}
+			names[ar.Name] = true
+		}
+		if v.required(p+".expr", ar.Expr) {
+			if _, err := rules.CompileCondition(ar.Expr); err != nil {
+				v.add(p+".expr", "%v", err)
+			}
+		}
+		if ar.For < 0 {
+			v.add(p+".for", "不能为负数")
+		}
+		if ar.Severity == "" {
+			ar.Severity = entity.SeverityWarning
+		}
+		v.oneOf(p+".severity", ar.Severity, entity.SeverityInfo, entity.SeverityWarning, entity.SeverityCritical)
+		if ar.Message != "" {
+			if _, err := rules.ParseMessage(ar.Name, ar.Message); err != nil {
+				v.add(p+".message", "%v", err)
+			}
+		}
+	}
+}
+
+// notifier 按渠道类型校验必填字段
+func (v *validator) notifier(p string, n *entity.NotifierConfig) {
+	switch n.Type {
+	case entity.NotifierDingTalk, entity.NotifierWeCom, entity.NotifierFeishu, entity.NotifierSlack, entity.NotifierWebhook:
+		v.httpURL(p+".webhook_url", n.WebhookURL)
+	case entity.NotifierEmail:
+		v.required(p+".smtp_host", n.SMTPHost)
+		if n.SMTPPort == 0 {
+			n.SMTPPort = DefaultSMTPPort
+		} else if n.SMTPPort < 0 || n.SMTPPort > 65535 {
+			v.add(p+".smtp_port", "无效的端口 %d", n.SMTPPort)
+		}
+		if n.SMTPPort == DefaultSMTPTLSPort {
+			n.SMTPTLS = true
+		}
+		v.required(p+".from", n.From)
+		if len(n.To) == 0 {
+			v.add(p+".to", "至少需要一个收件人")
+		}
+	}
+}
+
+func (v *validator) outbox(o *entity.OutboxConfig) {
+	if o.Dir == "" {
+		o.Dir = DefaultOutboxDir
+	}
+	if o.MaxAttempts < 0 {
+		v.add("outbox.max_attempts", "不能为负数")
+	} else if o.MaxAttempts == 0 {
+		o.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	v.duration("outbox.initial_backoff", &o.InitialBackoff, DefaultOutboxInitialBackoff)
+	v.duration("outbox.max_backoff", &o.MaxBackoff, DefaultOutboxMaxBackoff)
+	v.duration("outbox.dedup_window", &o.DedupWindow, DefaultOutboxDedupWindow)
+	if o.MaxBackoff < o.InitialBackoff {
+		v.add("outbox.max_backoff", "不能小于 initial_backoff (%v)", o.InitialBackoff)
+	}
+}
+
+func (v *validator) policyState(p *entity.PolicyStateConfig) {
+	if p.File == "" {
+		p.File = DefaultPolicyStateFile
+	}
+	v.duration("policy_state.save_interval", &p.SaveInterval, DefaultPolicyStateInterval)
+	v.duration("policy_state.max_age", &p.MaxAge, DefaultPolicyStateMaxAge)
+}
+
+func (v *validator) history(h *entity.HistoryConfig) {
+	if h.File == "" {
+		h.File = DefaultHistoryFile
+	}
+	v.duration("history.retention", &h.Retention, DefaultHistoryRetention)
+}
+
+// templates 自定义模板文件必须能读取并解析
+func (v *validator) templates(t *entity.TemplatesConfig) {
+	for _, item := range []struct{ name, path string }{
+		{templates.Alert, t.Alert},
+		{templates.Recovery, t.Recovery},
+		{templates.TickerReport, t.TickerReport},
+		{templates.ScheduledReport, t.ScheduledReport},
+	} {
+		if item.path == "" {
+			continue
+		}
+		if err := templates.Check(item.name, item.path); err != nil {
+			v.add("templates."+item.name, "%v", err)
+		}
+	}
+}
+
+// silences 校验维护窗口：名称唯一，cron 表达式、时区可解析，持续时间为正
+func (v *validator) silences(s *entity.SilencesConfig) {
+	if s.File == "" {
+		s.File = DefaultSilencesFile
+	}
+	names := map[string]bool{}
+	for i := range s.MaintenanceWindows {
+		w := &s.MaintenanceWindows[i]
+		p := fmt.Sprintf("silences.maintenance_windows[%d]", i)
+		if v.required(p+".name", w.Name) {
+			if names[w.Name] {
+				v.add(p+".name", "名称 %q 重复", w.Name)
+			}
+			names[w.Name] = true
+		}
+		if v.required(p+".schedule", w.Schedule) {
+			if _, err := utils.ParseCron(w.Schedule); err != nil {
+				v.add(p+".schedule", "%v", err)
+			}
+		}
+		if w.Duration <= 0 {
+			v.add(p+".duration", "必须大于 0")
+		}
+		if w.Timezone != "" {
+			if _, err := time.LoadLocation(w.Timezone); err != nil {
+				v.add(p+".timezone", "无效的时区 %q", w.Timezone)
+			}
+		}
+		v.patterns(p+".alert_types", w.AlertTypes)
+		v.patterns(p+".hosts", w.Hosts)
+		v.patterns(p+".targets", w.Targets)
+	}
+}
+
+func (v *validator) log(l *entity.LogConfig) {
+	if l.Mode == "" {
+		l.Mode = DefaultLogMode
+	}
+	v.oneOf("log.mode", l.Mode, "console", "file", "both")
+	if l.Level == "" {
+		l.Level = DefaultLogLevel
+	}
+	v.oneOf("log.level", l.Level, "debug", "info", "warn", "error")
+	if l.Mode != "console" {
+		v.required("log.output", l.Output)
+	}
+	if l.MaxSize < 0 {
+		v.add("log.max_size", "不能为负数")
+	}
+	if l.MaxAge < 0 {
+		v.add("log.max_age", "不能为负数")
+	}
+	if l.MaxBackups < 0 {
+		v.add("log.max_backups", "不能为负数")
+	}
+}
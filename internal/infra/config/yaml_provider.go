@@ -4,30 +4,151 @@ import (
 	domain "GWatch/internal/domain/config"
 	"GWatch/internal/entity"
 	"fmt"
+	"log"
 	"os"
-
-	"gopkg.in/yaml.v3"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// YAMLProvider 从指定路径加载 YAML 配置
+// watchInterval 配置文件变化检查间隔
+const watchInterval = 5 * time.Second
+
+// YAMLProvider 从指定路径加载 YAML 配置，支持热加载
 type YAMLProvider struct {
 	path string
-	cfg  *entity.Config
+	cfg  atomic.Pointer[entity.Config]
+
+	reloadMu    sync.Mutex // 串行化整个 Reload（加载与通知订阅者），保证订阅者按加载顺序收到配置
+	mu          sync.Mutex // 保护 includeDir 与订阅者列表
+	includeDir  string     // 最近一次成功加载时的 include 目录
+	subscribers []func(cfg *entity.Config)
 }
 
 func NewYAMLProvider(path string) (*YAMLProvider, error) {
-	data, err := os.ReadFile(path)
+	c, dir, err := loadConfig(path)
 	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %v", err)
+		return nil, err
 	}
-	var c entity.Config
-	if err := yaml.Unmarshal(data, &c); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %v", err)
+	p := &YAMLProvider{path: path, includeDir: dir}
+	p.cfg.Store(c)
+	return p, nil
+}
+
+func (p *YAMLProvider) GetConfig() *entity.Config { return p.cfg.Load() }
+
+// Reload 重新读取配置文件，校验通过后原子替换并通知订阅者
+// 文件监视与 SIGHUP 可能同时触发，通知完成前不开始下一次加载，否则较早加载的配置可能最后送达订阅者
+func (p *YAMLProvider) Reload() error {
+	p.reloadMu.Lock()
+	defer p.reloadMu.Unlock()
+
+	p.mu.Lock()
+	c, dir, err := loadConfig(p.path)
+	if err != nil {
+		p.mu.Unlock()
+		return fmt.Errorf("重新加载配置失败，继续使用旧配置: %v", err)
+	}
+	p.cfg.Store(c)
+	p.includeDir = dir
+	subscribers := append([]func(cfg *entity.Config){}, p.subscribers...)
+	p.mu.Unlock()
+
+	log.Printf("配置已重新加载: %s", p.path)
+	for _, fn := range subscribers {
+		fn(c)
+	}
+	return nil
+}
+
+// Subscribe 注册配置变更回调
+func (p *YAMLProvider) Subscribe(fn func(cfg *entity.Config)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = append(p.subscribers, fn)
+}
+
+// Watch 定期检查配置文件及 include 目录的变化，发生变化时自动重新加载
+func (p *YAMLProvider) Watch(stopCh <-chan struct{}, onError func(err error)) {
+	lastFingerprint := p.fingerprint()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fp := p.fingerprint()
+			if fp == "" || fp == lastFingerprint {
+				continue
+			}
+			// 先记录变化，同一个错误文件只通知一次
+			lastFingerprint = fp
+			log.Printf("检测到配置文件变化: %s", p.path)
+			if err := p.Reload(); err != nil {
+				log.Printf("[ERROR] %v", err)
+				if onError != nil {
+					onError(err)
+				}
+			}
+		case <-stopCh:
+			return
+		}
 	}
-	return &YAMLProvider{path: path, cfg: &c}, nil
 }
 
-func (p *YAMLProvider) GetConfig() *entity.Config { return p.cfg }
+// fingerprint 汇总基础配置文件与 include 目录下各文件的修改时间和大小
+// 文件新增、删除或修改都会改变结果；基础配置文件无法读取时返回空
+func (p *YAMLProvider) fingerprint() string {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:%d:%d;", p.path, info.ModTime().UnixNano(), info.Size())
+
+	p.mu.Lock()
+	dir := p.includeDir
+	p.mu.Unlock()
+	if dir == "" {
+		return b.String()
+	}
+	files, _ := includeFiles(dir)
+	for _, f := range files {
+		if fi, err := os.Stat(f); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", f, fi.ModTime().UnixNano(), fi.Size())
+		}
+	}
+	return b.String()
+}
+
+// LoadConfig 读取并校验配置文件（含 include 目录），不创建 Provider（供 check-config 等命令使用）
+func LoadConfig(path string) (*entity.Config, error) {
+	c, _, err := loadConfig(path)
+	return c, err
+}
+
+// loadConfig 读取并合并配置文件、展开变量引用、解析、填充默认值并校验
+// 同时返回 include 目录，供 Watch 监听
+func loadConfig(path string) (*entity.Config, string, error) {
+	root, dir, err := loadMergedNode(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := interpolateNode(root, ""); err != nil {
+		return nil, "", fmt.Errorf("展开配置变量失败: %v", err)
+	}
+	var c entity.Config
+	if len(root.Content) > 0 {
+		if err := root.Decode(&c); err != nil {
+			return nil, "", fmt.Errorf("解析配置文件失败: %v", err)
+		}
+	}
+	if err := Validate(&c); err != nil {
+		return nil, "", fmt.Errorf("配置校验失败: %v", err)
+	}
+	return &c, dir, nil
+}
 
 // 实现领域接口
 var _ domain.Provider = (*YAMLProvider)(nil)
@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
+// 日志级别，数值越大越重要
+var logLevels = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
 // LogWrapper 日志包装器，用于替换标准 log 包
 type LogWrapper struct {
 	logger logger.Logger
@@ -21,25 +25,76 @@ func NewLogWrapper(logger logger.Logger) *LogWrapper {
 }
 
 // InitLogWrapper 初始化全局日志包装器
-func InitLogWrapper(logger logger.Logger) {
+// level 为最低输出级别（debug/info/warn/error），低于该级别的日志被丢弃，空值等同 info
+func InitLogWrapper(logger logger.Logger, level string) {
 	wrapper := NewLogWrapper(logger)
 	
 	// 重定向标准 log 输出
 	log.SetFlags(0) // 移除默认的时间戳和文件信息
-	log.SetOutput(&logWriter{wrapper: wrapper})
+	log.SetOutput(&logWriter{wrapper: wrapper, minLevel: parseLogLevel(level)})
+}
+
+// InitStderrLog 单次执行的命令使用：标准 log 输出到 stderr 并按级别过滤，保持 stdout 只输出结果便于脚本解析
+func InitStderrLog(level string) {
+	log.SetOutput(&stderrWriter{minLevel: parseLogLevel(level)})
+}
+
+// parseLogLevel 解析日志级别，无法识别时按 info 处理
+func parseLogLevel(level string) int {
+	if l, ok := logLevels[strings.ToLower(level)]; ok {
+		return l
+	}
+	return logLevels["info"]
+}
+
+// IsValidLogLevel 判断日志级别是否受支持
+func IsValidLogLevel(level string) bool {
+	_, ok := logLevels[strings.ToLower(level)]
+	return ok
 }
 
 // logWriter 实现 io.Writer 接口
 type logWriter struct {
-	wrapper *LogWrapper
+	wrapper  *LogWrapper
+	minLevel int
 }
 
 func (w *logWriter) Write(p []byte) (n int, err error) {
+	if messageLevel(p) < w.minLevel {
+		return len(p), nil
+	}
 	// 使用我们的日志器输出
 	w.wrapper.logger.Info(string(p))
 	return len(p), nil
 }
 
+// stderrWriter 按级别过滤后写入 stderr
+type stderrWriter struct {
+	minLevel int
+}
+
+func (w *stderrWriter) Write(p []byte) (n int, err error) {
+	if messageLevel(p) < w.minLevel {
+		return len(p), nil
+	}
+	return os.Stderr.Write(p)
+}
+
+// messageLevel 根据消息中的 [DEBUG]/[WARN]/[ERROR] 标记判断级别，无标记视为 info
+func messageLevel(p []byte) int {
+	s := string(p)
+	switch {
+	case strings.Contains(s, "[ERROR]"):
+		return logLevels["error"]
+	case strings.Contains(s, "[WARN]"):
+		return logLevels["warn"]
+	case strings.Contains(s, "[DEBUG]"):
+		return logLevels["debug"]
+	default:
+		return logLevels["info"]
+	}
+}
+
 // 提供兼容标准 log 包的函数
 func (w *LogWrapper) Print(v ...interface{}) {
 	w.logger.Info(v...)
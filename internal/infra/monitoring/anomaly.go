@@ -0,0 +1,344 @@
+package monitoring
+
+import (
+	"GWatch/internal/domain/config"
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	anomalyLearnGap         = time.Minute         // 同一指标两次学习的最小间隔，一个周期内的多次评估与未更新的指标不重复学习
+	anomalyMinHourSamples   = 6                   // 一小时内至少学习到的采样数，不足时不更新该时段的基线
+	anomalyMinRelDeviation  = 0.05                // 标准差至少按均值的 5% 计算，避免长期平稳的指标稍有波动就告警
+	anomalySeriesRetention  = 30 * 24 * time.Hour // 超过该时长没有数据的指标（如已删除的实例）不再保存
+	anomalyDefaultSaveEvery = 5 * time.Minute
+)
+
+// anomalySpec 可检测的指标：取值方式、显示格式与标准差下限
+type anomalySpec struct {
+	metric string
+	format string
+	minStd float64 // 标准差下限（指标单位），避免取值恒定（如连接数恒为 0）时任何变化都视为异常
+	values func(m *entity.SystemMetrics) []anomalyValue
+}
+
+// anomalyValue 指标在某个实例上的观测值，instance 为空表示单实例指标
+type anomalyValue struct {
+	instance string
+	value    float64
+}
+
+var anomalySpecs = []anomalySpec{
+	{entity.AnomalyCPU, "%.2f%%", 1, func(m *entity.SystemMetrics) []anomalyValue {
+		if m.CPU.Error != nil {
+			return nil
+		}
+		return []anomalyValue{{value: m.CPU.Percent}}
+	}},
+	{entity.AnomalyMemory, "%.2f%%", 1, func(m *entity.SystemMetrics) []anomalyValue {
+		if m.Memory.Error != nil {
+			return nil
+		}
+		return []anomalyValue{{value: m.Memory.Percent}}
+	}},
+	{entity.AnomalyNetworkDownload, "%.2f KB/s", 10, func(m *entity.SystemMetrics) []anomalyValue {
+		if m.Network.Error != nil {
+			return nil
+		}
+		return []anomalyValue{{value: m.Network.DownloadKBps}}
+	}},
+	{entity.AnomalyNetworkUpload, "%.2f KB/s", 10, func(m *entity.SystemMetrics) []anomalyValue {
+		if m.Network.Error != nil {
+			return nil
+		}
+		return []anomalyValue{{value: m.Network.UploadKBps}}
+	}},
+	{entity.AnomalyRedisClients, "%.0f 个连接", 2, func(m *entity.SystemMetrics) []anomalyValue {
+		var values []anomalyValue
+		for _, r := range m.Redis {
+			if r.ConnectionError == nil {
+				values = append(values, anomalyValue{r.Name, float64(r.ClientCount)})
+			}
+		}
+		return values
+	}},
+	{entity.AnomalyMySQLQPS, "%.0f", 5, func(m *entity.SystemMetrics) []anomalyValue {
+		var values []anomalyValue
+		for _, r := range m.MySQL {
+			if r.Error == nil {
+				values = append(values, anomalyValue{r.Name, r.QueryPerformance.QueriesPerSecond})
+			}
+		}
+		return values
+	}},
+	{entity.AnomalyMySQLThreads, "%.0f 个线程", 2, func(m *entity.SystemMetrics) []anomalyValue {
+		var values []anomalyValue
+		for _, r := range m.MySQL {
+			if r.Error == nil {
+				values = append(values, anomalyValue{r.Name, float64(r.Connections.ThreadsRunning)})
+			}
+		}
+		return values
+	}},
+	{entity.AnomalyHTTPResponseTime, "%.0f ms", 20, func(m *entity.SystemMetrics) []anomalyValue {
+		var values []anomalyValue
+		for _, h := range m.HTTP.Interfaces {
+			if h.IsAccessible && h.Error == nil && h.StatusCode > 0 {
+				values = append(values, anomalyValue{h.Name, float64(h.ResponseTime) / float64(time.Millisecond)})
+			}
+		}
+		return values
+	}},
+}
+
+// baselineStat 某个时段的 EWMA 基线
+type baselineStat struct {
+	Mean    float64 `json:"mean"`
+	Var     float64 `json:"var"`
+	Periods int     `json:"periods"` // 已学习的次数
+}
+
+// update 用一个时段（一小时）的均值与方差更新基线：方差为观测值相对基线均值的偏差平方的 EWMA
+func (s *baselineStat) update(mean, variance, alpha float64) {
+	if s.Periods == 0 {
+		s.Mean, s.Var = mean, variance
+	} else {
+		d := mean - s.Mean
+		s.Mean += alpha * d
+		s.Var = (1-alpha)*s.Var + alpha*(variance+d*d)
+	}
+	s.Periods++
+}
+
+// hourAccumulator 当前小时内的采样累计，小时结束时更新对应时段的基线
+type hourAccumulator struct {
+	Hour  time.Time `json:"hour"`
+	N     int       `json:"n"`
+	Sum   float64   `json:"sum"`
+	SumSq float64   `json:"sum_sq"`
+}
+
+// baselineSeries 单个指标（指标 + 实例）的基线：按小时（0-23）与按星期 + 小时（周日 0 点为 0）
+type baselineSeries struct {
+	Hourly   [24]baselineStat     `json:"hourly"`
+	Weekly   [7 * 24]baselineStat `json:"weekly"`
+	Current  hourAccumulator      `json:"current"`
+	LastSeen time.Time            `json:"last_seen"`
+
+	pending time.Time // 本次开始偏离基线的时间，未偏离为零值
+}
+
+// learn 累计采样，进入新的小时后用上一小时的采样更新基线
+func (s *baselineSeries) learn(at time.Time, value, alpha float64) {
+	hour := time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), 0, 0, 0, at.Location())
+	if c := s.Current; !c.Hour.Equal(hour) {
+		if c.N >= anomalyMinHourSamples {
+			mean := c.Sum / float64(c.N)
+			variance := math.Max(c.SumSq/float64(c.N)-mean*mean, 0)
+			local := c.Hour.In(at.Location())
+			s.Hourly[local.Hour()].update(mean, variance, alpha)
+			s.Weekly[int(local.Weekday())*24+local.Hour()].update(mean, variance, alpha)
+		}
+		s.Current = hourAccumulator{Hour: hour}
+	}
+	s.Current.N++
+	s.Current.Sum += value
+	s.Current.SumSq += value * value
+	s.LastSeen = at
+}
+
+// baseline 返回 at 所在时段的基线：优先按星期 + 小时，学习次数不足时使用按小时的基线
+func (s *baselineSeries) baseline(at time.Time, minPeriods int) (baselineStat, string, bool) {
+	if w := s.Weekly[int(at.Weekday())*24+at.Hour()]; w.Periods >= minPeriods {
+		return w, "每周同一时段", true
+	}
+	if h := s.Hourly[at.Hour()]; h.Periods >= minPeriods {
+		return h, "每天同一时段", true
+	}
+	return baselineStat{}, "", false
+}
+
+// baselineFile 基线文件内容，键为 指标 或 指标:实例
+type baselineFile struct {
+	SavedAt time.Time                  `json:"saved_at"`
+	Series  map[string]*baselineSeries `json:"series"`
+}
+
+// AnomalyEvaluator 在其余判断之外按 anomaly 配置做动态基线异常检测：
+// 每个指标按所在时段的基线计算偏离的标准差倍数，超过 sigma 并持续 for 时长后产生 anomaly 决策，告警对象为 指标 或 指标:实例
+// 同时实现基线的保存与恢复
+type AnomalyEvaluator struct {
+	next     domainMonitor.Evaluator
+	provider config.Provider
+
+	mu     sync.Mutex
+	series map[string]*baselineSeries
+}
+
+// NewAnomalyEvaluator 创建异常检测评估器，next 为其余判断（内置阈值与自定义规则）
+func NewAnomalyEvaluator(provider config.Provider, next domainMonitor.Evaluator) *AnomalyEvaluator {
+	return &AnomalyEvaluator{next: next, provider: provider, series: map[string]*baselineSeries{}}
+}
+
+func (e *AnomalyEvaluator) Evaluate(cfg *entity.Config, metrics *entity.SystemMetrics) ([]domainMonitor.Decision, error) {
+	decisions, err := e.next.Evaluate(cfg, metrics)
+	if err != nil || cfg.Anomaly == nil {
+		return decisions, err
+	}
+	a := cfg.Anomaly
+	now := time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, spec := range anomalySpecs {
+		if !a.Detects(spec.metric) {
+			continue
+		}
+		for _, v := range spec.values(metrics) {
+			target := spec.metric
+			if v.instance != "" {
+				target += ":" + v.instance
+			}
+			s := e.series[target]
+			if s == nil {
+				s = &baselineSeries{}
+				e.series[target] = s
+			}
+			if d, ok := e.detect(a, spec, target, s, v.value, now); ok {
+				decisions = append(decisions, d)
+			}
+			if now.Sub(s.LastSeen) >= anomalyLearnGap {
+				s.learn(now, v.value, a.Alpha)
+			}
+		}
+	}
+	return decisions, nil
+}
+
+// detect 按所在时段的基线判断是否偏离，偏离持续 for 时长后返回决策
+// 调用方需持有 e.mu
+func (e *AnomalyEvaluator) detect(a *entity.AnomalyConfig, spec anomalySpec, target string, s *baselineSeries, value float64, now time.Time) (domainMonitor.Decision, bool) {
+	base, period, ok := s.baseline(now, a.MinPeriods)
+	if !ok {
+		s.pending = time.Time{}
+		return domainMonitor.Decision{}, false
+	}
+	std := math.Max(math.Sqrt(base.Var), math.Max(spec.minStd, anomalyMinRelDeviation*math.Abs(base.Mean)))
+	sigma := (value - base.Mean) / std
+	if math.Abs(sigma) <= a.Sigma {
+		s.pending = time.Time{}
+		return domainMonitor.Decision{}, false
+	}
+	if s.pending.IsZero() {
+		s.pending = now
+	}
+	if now.Sub(s.pending) < a.For {
+		return domainMonitor.Decision{}, false
+	}
+
+	direction := "高于"
+	if sigma < 0 {
+		direction = "低于"
+	}
+	severity := entity.SeverityWarning
+	if a.CriticalSigma > 0 && math.Abs(sigma) > a.CriticalSigma {
+		severity = entity.SeverityCritical
+	}
+	return domainMonitor.Decision{
+		Type:     entity.Anomaly,
+		Target:   target,
+		Value:    value,
+		Severity: severity,
+		Message: fmt.Sprintf("%s: 当前 %s，%s基线 %s ± %s（%s），偏离 %.1f 个标准差已持续 %v",
+			entity.Anomaly.Describe(target), fmt.Sprintf(spec.format, value), direction,
+			fmt.Sprintf(spec.format, base.Mean), fmt.Sprintf(spec.format, std), period,
+			math.Abs(sigma), now.Sub(s.pending).Round(time.Second)),
+	}, true
+}
+
+// Restore 读取基线文件，文件不存在时从空基线开始学习
+func (e *AnomalyEvaluator) Restore() {
+	cfg := e.provider.GetConfig()
+	if cfg == nil || cfg.Anomaly == nil {
+		return
+	}
+	path := cfg.Anomaly.File
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] 读取异常检测基线文件 %s 失败: %v", path, err)
+		return
+	}
+	var f baselineFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		log.Printf("[ERROR] 解析异常检测基线文件 %s 失败，忽略: %v", path, err)
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series = map[string]*baselineSeries{}
+	for target, s := range f.Series {
+		if s != nil {
+			e.series[target] = s
+		}
+	}
+	log.Printf("已恢复异常检测基线（保存于 %v 前），指标 %d 个", time.Since(f.SavedAt).Round(time.Second), len(e.series))
+}
+
+// Run 按 anomaly.save_interval 定期保存基线，直到 stopCh 关闭；退出前的保存由调用方在监控循环结束后调用 Save
+func (e *AnomalyEvaluator) Run(stopCh <-chan struct{}) {
+	interval := e.saveInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := e.Save(); err != nil {
+				log.Printf("[ERROR] 保存异常检测基线失败: %v", err)
+			}
+			if next := e.saveInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (e *AnomalyEvaluator) saveInterval() time.Duration {
+	if cfg := e.provider.GetConfig(); cfg != nil && cfg.Anomaly != nil && cfg.Anomaly.SaveInterval > 0 {
+		return cfg.Anomaly.SaveInterval
+	}
+	return anomalyDefaultSaveEvery
+}
+
+// Save 将基线写入基线文件，未启用异常检测时不做任何事；长期没有数据的指标不再保存
+func (e *AnomalyEvaluator) Save() error {
+	cfg := e.provider.GetConfig()
+	if cfg == nil || cfg.Anomaly == nil {
+		return nil
+	}
+	now := time.Now()
+	e.mu.Lock()
+	f := baselineFile{SavedAt: now, Series: map[string]*baselineSeries{}}
+	for target, s := range e.series {
+		if now.Sub(s.LastSeen) > anomalySeriesRetention {
+			delete(e.series, target)
+			continue
+		}
+		copied := *s
+		f.Series[target] = &copied
+	}
+	e.mu.Unlock()
+	return writeJSONFile(cfg.Anomaly.File, f)
+}
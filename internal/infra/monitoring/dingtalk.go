@@ -1,23 +1,22 @@
 package monitoring
 
 import (
-	domaincfg "GWatch/internal/domain/config"
-	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"strings"
 
 	"github.com/youxihu/dingtalk/dingtalk"
 )
 
-// DingTalkNotifier implements Notifier using YouXiHu/dingtalk.
-type DingTalkNotifier struct{ provider domaincfg.Provider }
+// dingTalkChannel 钉钉机器人渠道，使用 YouXiHu/dingtalk 发送
+type dingTalkChannel struct{ cfg entity.NotifierConfig }
 
-func NewDingTalkNotifier(p domaincfg.Provider) monitoring.Notifier {
-	return &DingTalkNotifier{provider: p}
-}
+// dingTalkSeverityColors 钉钉 markdown 支持字体颜色，为告警级别标签着色
+var dingTalkSeverityColors = strings.NewReplacer(
+	"[严重]", "<font color=#FF0000>[严重]</font>",
+	"[警告]", "<font color=#FF9900>[警告]</font>",
+)
 
-func (d *DingTalkNotifier) Send(title string, markdown string) error {
-	cfg := d.provider.GetConfig()
-	if cfg == nil {
-		return nil
-	}
-	return dingtalk.SendDingDingNotification(cfg.DingTalk.WebhookURL, cfg.DingTalk.Secret, title, markdown, cfg.DingTalk.AtMobiles, false)
+func (d *dingTalkChannel) Send(title string, markdown string) error {
+	markdown = dingTalkSeverityColors.Replace(markdown)
+	return dingtalk.SendDingDingNotification(d.cfg.WebhookURL, d.cfg.Secret, title, markdown, d.cfg.AtMobiles, false)
 }
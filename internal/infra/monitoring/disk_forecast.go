@@ -0,0 +1,162 @@
+package monitoring
+
+import (
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	diskForecastMinSamples = 3                    // 拟合至少需要的采样数
+	diskForecastSampleGap  = 30 * time.Second     // 相邻采样的最小间隔，同一时刻的多次评估（如定时推送）不重复采样
+	diskForecastMaxETA     = 365 * 24 * time.Hour // 推算写满时间超过一年视为没有增长
+)
+
+// diskSample 挂载点的一次使用率采样
+type diskSample struct {
+	at      time.Time
+	percent float64
+}
+
+// DiskForecastEvaluator 在内置阈值判断之外，按 host_monitoring.disks.forecast 预测各挂载点的写满时间：
+// 保留最近 window 内的使用率采样并做最小二乘线性拟合，预计写满时间短于 horizon 时产生 disk_fill_predicted 决策
+// 采样只保存在内存中，重启后重新积累
+type DiskForecastEvaluator struct {
+	builtin domainMonitor.Evaluator
+
+	mu        sync.Mutex
+	samples   map[string][]diskSample        // 挂载点 -> window 内的采样，按时间排序
+	forecasts map[string]entity.DiskForecast // 挂载点 -> 最近一次评估的预测，使用率未增长时没有
+}
+
+// NewDiskForecastEvaluator 创建磁盘写满预测评估器，builtin 为内置阈值判断
+func NewDiskForecastEvaluator(builtin domainMonitor.Evaluator) *DiskForecastEvaluator {
+	return &DiskForecastEvaluator{builtin: builtin, samples: map[string][]diskSample{}, forecasts: map[string]entity.DiskForecast{}}
+}
+
+func (e *DiskForecastEvaluator) Evaluate(cfg *entity.Config, metrics *entity.SystemMetrics) ([]domainMonitor.Decision, error) {
+	decisions, err := e.builtin.Evaluate(cfg, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	h := cfg.HostMonitoring
+	if h == nil || !h.Enabled || h.Disks == nil || h.Disks.Forecast == nil {
+		e.samples = map[string][]diskSample{}
+		e.forecasts = map[string]entity.DiskForecast{}
+		return decisions, nil
+	}
+	if metrics.Disk.Error != nil || metrics.Disk.MountsError != nil {
+		return decisions, nil // 采集失败时保留已有采样
+	}
+
+	f := h.Disks.Forecast
+	now := metrics.Timestamp
+	if now.IsZero() {
+		now = time.Now()
+	}
+	seen := map[string]bool{}
+	for _, mount := range metrics.Disk.Mounts {
+		seen[mount.Mount] = true
+		fc, ok := forecastDisk(mount.Mount, e.record(mount.Mount, now, mount.Percent, f.Window), f.MinSpan)
+		if !ok {
+			delete(e.forecasts, mount.Mount)
+			continue
+		}
+		e.forecasts[mount.Mount] = fc
+		if fc.ETA >= f.Horizon {
+			continue
+		}
+		severity := entity.SeverityWarning
+		if f.CriticalHorizon > 0 && fc.ETA < f.CriticalHorizon {
+			severity = entity.SeverityCritical
+		}
+		decisions = append(decisions, domainMonitor.Decision{
+			Type:     entity.DiskFillPredicted,
+			Target:   mount.Mount,
+			Value:    fc.ETA.Hours(),
+			Severity: severity,
+			Message: fmt.Sprintf("%s: 预计 %s 后写满（%s），当前 %.2f%%，每小时增长 %.2f%%",
+				entity.DiskFillPredicted.Describe(mount.Mount), fc.ETAText(), fc.FullAt.Format("01-02 15:04"), fc.Percent, fc.RatePerHour),
+		})
+	}
+	// 不再监控的挂载点（卸载或被过滤）不再保留采样
+	for mount := range e.samples {
+		if !seen[mount] {
+			delete(e.samples, mount)
+			delete(e.forecasts, mount)
+		}
+	}
+	return decisions, nil
+}
+
+// record 追加采样并丢弃 window 之前的采样，返回该挂载点当前的全部采样
+// 调用方需持有 e.mu
+func (e *DiskForecastEvaluator) record(mount string, at time.Time, percent float64, window time.Duration) []diskSample {
+	samples := e.samples[mount]
+	if n := len(samples); n == 0 || at.Sub(samples[n-1].at) >= diskForecastSampleGap {
+		samples = append(samples, diskSample{at: at, percent: percent})
+	}
+	i := 0
+	for i < len(samples) && at.Sub(samples[i].at) > window {
+		i++
+	}
+	samples = samples[i:]
+	e.samples[mount] = samples
+	return samples
+}
+
+// forecastDisk 对采样做最小二乘线性拟合，以最近一次使用率按拟合的增长速度推算写满时间
+// 采样不足、覆盖时长不足 minSpan 或使用率没有明显增长时返回 false
+func forecastDisk(mount string, samples []diskSample, minSpan time.Duration) (entity.DiskForecast, bool) {
+	n := len(samples)
+	if n < diskForecastMinSamples || samples[n-1].at.Sub(samples[0].at) < minSpan {
+		return entity.DiskForecast{}, false
+	}
+	// x 为相对第一个采样的小时数，y 为使用率
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(samples[0].at).Hours()
+		sumX += x
+		sumY += s.percent
+		sumXY += x * s.percent
+		sumXX += x * x
+	}
+	denominator := float64(n)*sumXX - sumX*sumX
+	if denominator == 0 {
+		return entity.DiskForecast{}, false
+	}
+	slope := (float64(n)*sumXY - sumX*sumY) / denominator
+	last := samples[n-1]
+	hours := max(100-last.percent, 0) / slope
+	if slope <= 0 || hours >= diskForecastMaxETA.Hours() {
+		return entity.DiskForecast{}, false
+	}
+	eta := time.Duration(hours * float64(time.Hour))
+	return entity.DiskForecast{
+		Mount:       mount,
+		Percent:     last.percent,
+		RatePerHour: slope,
+		ETA:         eta,
+		FullAt:      last.at.Add(eta),
+	}, true
+}
+
+// Forecasts 返回预计在 within 内写满的挂载点，按写满时间排序
+func (e *DiskForecastEvaluator) Forecasts(within time.Duration) []entity.DiskForecast {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []entity.DiskForecast
+	for _, fc := range e.forecasts {
+		if fc.ETA < within {
+			result = append(result, fc)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ETA < result[j].ETA })
+	return result
+}
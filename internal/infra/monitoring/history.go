@@ -0,0 +1,177 @@
+package monitoring
+
+import (
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	historyBucket      = "alerts"
+	historyLockTimeout = 5 * time.Second // 等待其他进程（如 history 命令）释放文件锁的时长
+	historyPruneTick   = time.Hour
+)
+
+// BoltHistory 基于 bbolt 的告警历史，记录以 JSON 保存，键为事件时间（纳秒，大端）+ 序号，按时间有序
+// 每次写入时打开数据库、写完即关闭，运行中的进程不长期持有文件锁，history 命令可以随时读取
+type BoltHistory struct {
+	mu        sync.Mutex
+	lastPrune time.Time
+}
+
+func NewBoltHistory() monitoring.AlertHistory {
+	return &BoltHistory{}
+}
+
+// Record 追加告警事件，并按 history.retention 每小时清理一次过期记录
+func (h *BoltHistory) Record(cfg *entity.Config, events ...entity.AlertEvent) error {
+	if cfg == nil || !cfg.History.IsEnabled() || len(events) == 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	path := cfg.History.File
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: historyLockTimeout})
+	if err != nil {
+		return fmt.Errorf("打开告警历史 %s 失败: %w", path, err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	prune := now.Sub(h.lastPrune) >= historyPruneTick
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(historyBucket))
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if e.Time.IsZero() {
+				e.Time = now
+			}
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(historyKey(e.Time, seq), data); err != nil {
+				return err
+			}
+		}
+		if prune {
+			return pruneHistory(b, now.Add(-cfg.History.Retention))
+		}
+		return nil
+	})
+	if err == nil && prune {
+		h.lastPrune = now
+	}
+	return err
+}
+
+// historyKey 事件时间（纳秒）+ 序号，同一时刻的多条记录保持写入顺序
+func historyKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+// pruneHistory 删除 before 之前的记录
+func pruneHistory(b *bolt.Bucket, before time.Time) error {
+	end := historyKey(before, 0)
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryHistory 按条件查询告警历史，结果按时间从早到晚排序；数据库文件不存在时返回空结果
+// 以只读方式打开，可在监控进程运行时调用
+func QueryHistory(path string, q entity.HistoryQuery) ([]entity.AlertEvent, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{ReadOnly: true, Timeout: historyLockTimeout})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("打开告警历史 %s 失败: %w", path, err)
+	}
+	defer db.Close()
+
+	var result []entity.AlertEvent
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(historyBucket))
+		if b == nil {
+			return nil
+		}
+		// 从 until 向前遍历，取到 limit 条即停止
+		c := b.Cursor()
+		var k, v []byte
+		if q.Until.IsZero() {
+			k, v = c.Last()
+		} else if k, v = c.Seek(historyKey(q.Until, ^uint64(0))); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		var since []byte
+		if !q.Since.IsZero() {
+			since = historyKey(q.Since, 0)
+		}
+		for ; k != nil && (since == nil || bytes.Compare(k, since) >= 0); k, v = c.Prev() {
+			var e entity.AlertEvent
+			if err := json.Unmarshal(v, &e); err != nil {
+				continue
+			}
+			if !historyMatches(q, e) {
+				continue
+			}
+			result = append(result, e)
+			if q.Limit > 0 && len(result) >= q.Limit {
+				break
+			}
+		}
+		return nil
+	})
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, err
+}
+
+// historyMatches 事件类型、告警类型、主机与对象条件均满足（未指定的条件不过滤）
+func historyMatches(q entity.HistoryQuery, e entity.AlertEvent) bool {
+	if len(q.Events) > 0 && !utils.MatchAny(q.Events, e.Event) {
+		return false
+	}
+	if len(q.Types) > 0 && !utils.MatchAny(q.Types, string(e.Type)) {
+		return false
+	}
+	if len(q.Hosts) > 0 && !utils.MatchAny(q.Hosts, e.Host) && !utils.MatchAny(q.Hosts, e.IP) {
+		return false
+	}
+	if len(q.Targets) > 0 && !utils.MatchAny(q.Targets, e.Target) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,170 @@
+package monitoring
+
+import (
+	domaincfg "GWatch/internal/domain/config"
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MultiNotifier 将通知扇出到所有已配置的渠道（dingtalk 及 notifiers），单个渠道失败不影响其他渠道
+// 启用发件箱时经发件箱发送，失败的通知会在后台重试
+type MultiNotifier struct {
+	provider domaincfg.Provider
+	outbox   *Outbox
+}
+
+// NewNotifier 创建多渠道通知器，每次发送时读取最新配置以支持热加载
+func NewNotifier(p domaincfg.Provider, outbox *Outbox) monitoring.Notifier {
+	return &MultiNotifier{provider: p, outbox: outbox}
+}
+
+func (m *MultiNotifier) Send(title string, markdown string) error {
+	cfg := m.provider.GetConfig()
+	if cfg == nil {
+		return nil
+	}
+	return m.send(cfg, cfg.NotificationChannels(), title, markdown)
+}
+
+func (m *MultiNotifier) SendTo(channels []string, atMobiles []string, title string, markdown string) error {
+	cfg := m.provider.GetConfig()
+	if cfg == nil {
+		return nil
+	}
+	byName := map[string]entity.NotifierConfig{}
+	for _, ch := range cfg.NotificationChannels() {
+		byName[ch.Name] = ch
+	}
+	var selected []entity.NotifierConfig
+	for _, name := range channels {
+		ch, ok := byName[name]
+		if !ok {
+			log.Printf("[WARN] 通知渠道 %s 不存在或未启用，已跳过", name)
+			continue
+		}
+		ch.AtMobiles = mergeMobiles(ch.AtMobiles, atMobiles)
+		selected = append(selected, ch)
+	}
+	return m.send(cfg, selected, title, markdown)
+}
+
+func (m *MultiNotifier) send(cfg *entity.Config, channels []entity.NotifierConfig, title string, markdown string) error {
+	var errs []error
+	for _, ch := range channels {
+		var err error
+		if m.outbox != nil && m.outbox.enabled(cfg) {
+			err = m.outbox.Deliver(cfg, ch, title, markdown)
+		} else {
+			err = sendChannel(ch, title, markdown)
+		}
+		if err != nil {
+			log.Printf("[ERROR] 通知渠道 %s 发送失败: %v", ch.Name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", ch.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mergeMobiles 合并 @ 列表并去重，不修改入参
+func mergeMobiles(base, extra []string) []string {
+	if len(extra) == 0 {
+		return base
+	}
+	merged := make([]string, 0, len(base)+len(extra))
+	seen := map[string]bool{}
+	for _, m := range append(append([]string{}, base...), extra...) {
+		if !seen[m] {
+			seen[m] = true
+			merged = append(merged, m)
+		}
+	}
+	return merged
+}
+
+// sendChannel 直接发送到单个渠道
+func sendChannel(ch entity.NotifierConfig, title, markdown string) error {
+	n, err := newChannelNotifier(ch)
+	if err != nil {
+		return err
+	}
+	return n.Send(title, markdown)
+}
+
+// channelNotifier 单个通知渠道
+type channelNotifier interface {
+	Send(title string, markdown string) error
+}
+
+// newChannelNotifier 按渠道类型创建通知器
+func newChannelNotifier(c entity.NotifierConfig) (channelNotifier, error) {
+	switch c.Type {
+	case entity.NotifierDingTalk:
+		return &dingTalkChannel{cfg: c}, nil
+	case entity.NotifierEmail:
+		return &emailChannel{cfg: c}, nil
+	case entity.NotifierWeCom:
+		return &weComChannel{cfg: c}, nil
+	case entity.NotifierFeishu:
+		return &feishuChannel{cfg: c}, nil
+	case entity.NotifierSlack:
+		return &slackChannel{cfg: c}, nil
+	case entity.NotifierWebhook:
+		return &webhookChannel{cfg: c}, nil
+	default:
+		return nil, fmt.Errorf("不支持的通知渠道类型 %q", c.Type)
+	}
+}
+
+var notifyHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// postJSON 以 JSON 发送请求，非 2xx 状态码视为失败，返回响应体供调用方检查业务错误码
+func postJSON(url string, payload any, headers map[string]string) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := notifyHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return respBody, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+var (
+	mdHeading = regexp.MustCompile(`(?m)^#{1,6}\s+(.+?)\s*$`)
+	mdBold    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+)
+
+// headingsToBold 将 markdown 标题转换为粗体行（飞书/Slack 等不支持标题语法）
+func headingsToBold(markdown string) string {
+	return mdHeading.ReplaceAllString(markdown, "**$1**")
+}
+
+// markdownToPlain 去除 markdown 标记，用于纯文本渠道（邮件）
+func markdownToPlain(markdown string) string {
+	text := mdHeading.ReplaceAllString(markdown, "$1")
+	return mdBold.ReplaceAllString(text, "$1")
+}
@@ -0,0 +1,56 @@
+package monitoring
+
+import (
+	"GWatch/internal/entity"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// feishuChannel 飞书/Lark 自定义机器人渠道，以消息卡片发送 markdown
+type feishuChannel struct{ cfg entity.NotifierConfig }
+
+func (f *feishuChannel) Send(title string, markdown string) error {
+	payload := map[string]any{
+		"msg_type": "interactive",
+		"card": map[string]any{
+			"header": map[string]any{
+				"title": map[string]string{"tag": "plain_text", "content": title},
+			},
+			"elements": []map[string]string{
+				{"tag": "markdown", "content": headingsToBold(markdown)},
+			},
+		},
+	}
+	if f.cfg.Secret != "" {
+		ts := time.Now().Unix()
+		payload["timestamp"] = strconv.FormatInt(ts, 10)
+		payload["sign"] = feishuSign(ts, f.cfg.Secret)
+	}
+
+	body, err := postJSON(f.cfg.WebhookURL, payload, nil)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("飞书返回错误 %d: %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// feishuSign 飞书签名：以 timestamp+"\n"+secret 为密钥对空串做 HmacSHA256 后 base64
+func feishuSign(ts int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(ts, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,14 @@
+package monitoring
+
+import (
+	"GWatch/internal/entity"
+)
+
+// slackChannel Slack incoming webhook 渠道，markdown 转为 mrkdwn
+type slackChannel struct{ cfg entity.NotifierConfig }
+
+func (s *slackChannel) Send(title string, markdown string) error {
+	text := mdBold.ReplaceAllString(headingsToBold(markdown), "*$1*")
+	_, err := postJSON(s.cfg.WebhookURL, map[string]string{"text": text}, nil)
+	return err
+}
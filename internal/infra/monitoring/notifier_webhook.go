@@ -0,0 +1,21 @@
+package monitoring
+
+import (
+	"GWatch/internal/entity"
+	"time"
+)
+
+// webhookChannel 通用 JSON webhook 渠道，便于对接自建告警平台
+type webhookChannel struct{ cfg entity.NotifierConfig }
+
+// webhookPayload 通用 webhook 请求体
+type webhookPayload struct {
+	Title     string    `json:"title"`
+	Content   string    `json:"content"` // markdown 格式
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (w *webhookChannel) Send(title string, markdown string) error {
+	_, err := postJSON(w.cfg.WebhookURL, webhookPayload{Title: title, Content: markdown, Timestamp: time.Now()}, w.cfg.Headers)
+	return err
+}
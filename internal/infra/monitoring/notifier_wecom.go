@@ -0,0 +1,48 @@
+package monitoring
+
+import (
+	"GWatch/internal/entity"
+	"encoding/json"
+	"fmt"
+)
+
+// weComChannel 企业微信群机器人渠道
+type weComChannel struct{ cfg entity.NotifierConfig }
+
+func (w *weComChannel) Send(title string, markdown string) error {
+	if err := w.post(map[string]any{
+		"msgtype":  "markdown",
+		"markdown": map[string]string{"content": markdown},
+	}); err != nil {
+		return err
+	}
+	// markdown 消息不支持 @手机号，单独补发一条 text 消息提醒
+	if len(w.cfg.AtMobiles) == 0 {
+		return nil
+	}
+	return w.post(map[string]any{
+		"msgtype": "text",
+		"text": map[string]any{
+			"content":               title,
+			"mentioned_mobile_list": w.cfg.AtMobiles,
+		},
+	})
+}
+
+func (w *weComChannel) post(payload any) error {
+	body, err := postJSON(w.cfg.WebhookURL, payload, nil)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("企业微信返回错误 %d: %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package monitoring
+
+import (
+	domaincfg "GWatch/internal/domain/config"
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	outboxPendingDir = "pending"
+	outboxFailedDir  = "failed"
+	outboxTick       = 5 * time.Second
+)
+
+// outboxMessage 发往单个渠道的一条通知，以 JSON 文件保存在 <dir>/pending 下
+type outboxMessage struct {
+	ID          string    `json:"id"`
+	Hash        string    `json:"hash"` // 渠道+标题+正文+@列表 的摘要，用于去重
+	Channel     string    `json:"channel"`
+	AtMobiles   []string  `json:"at_mobiles,omitempty"`
+	Title       string    `json:"title"`
+	Markdown    string    `json:"markdown"`
+	CreatedAt   time.Time `json:"created_at"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+
+	inflight bool
+}
+
+// Outbox 基于本地目录的通知发件箱
+// 每条通知先落盘再发送：发送成功删除文件，失败按指数退避+随机抖动重试，超过最大次数移入 <dir>/failed；
+// 同时按渠道的 rate_limit_per_minute 限流（钉钉机器人每分钟 20 条），超出的通知排队等待
+type Outbox struct {
+	provider domaincfg.Provider
+	dir      string // 为空表示启动时未启用发件箱
+
+	mu        sync.Mutex
+	pending   map[string]*outboxMessage
+	failed    int
+	delivered map[string]time.Time   // 消息摘要 -> 最近一次发送成功的时间
+	sent      map[string][]time.Time // 渠道 -> 最近一分钟内的发送时间
+	lastStats monitoring.OutboxStats
+}
+
+// NewOutbox 创建通知发件箱并加载上次未发送完成的通知；目录在启动时确定，热加载修改 dir 需重启生效
+func NewOutbox(p domaincfg.Provider) *Outbox {
+	o := NewDirectOutbox(p)
+	cfg := p.GetConfig()
+	if cfg == nil || !cfg.Outbox.IsEnabled() {
+		return o
+	}
+	o.dir = cfg.Outbox.Dir
+	if err := o.load(); err != nil {
+		log.Printf("[ERROR] 加载通知发件箱 %s 失败: %v", o.dir, err)
+	}
+	if stats := o.Stats(); stats.Pending > 0 || stats.Failed > 0 {
+		log.Printf("通知发件箱: 待发送 %d 条，发送失败 %d 条", stats.Pending, stats.Failed)
+		o.lastStats = stats
+	}
+	return o
+}
+
+// NewDirectOutbox 创建不落盘的发件箱：通知直接发送，不写入目录、不因限流排队、失败不重试，
+// 用于 once/test-notify/push-now 等单次执行的命令，避免未发送的通知留给下次启动的进程
+func NewDirectOutbox(p domaincfg.Provider) *Outbox {
+	return &Outbox{
+		provider:  p,
+		pending:   map[string]*outboxMessage{},
+		delivered: map[string]time.Time{},
+		sent:      map[string][]time.Time{},
+	}
+}
+
+// enabled 发件箱是否生效（启动时启用且当前配置未关闭）
+func (o *Outbox) enabled(cfg *entity.Config) bool {
+	return o.dir != "" && cfg.Outbox.IsEnabled()
+}
+
+func (o *Outbox) Stats() monitoring.OutboxStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return monitoring.OutboxStats{Pending: len(o.pending), Failed: o.failed}
+}
+
+// Deliver 将通知写入发件箱并立即尝试发送一次；被限流时排队，发送失败时返回错误并留待重试
+func (o *Outbox) Deliver(cfg *entity.Config, ch entity.NotifierConfig, title, markdown string) error {
+	now := time.Now()
+	msg := &outboxMessage{
+		Channel:     ch.Name,
+		AtMobiles:   ch.AtMobiles,
+		Title:       title,
+		Markdown:    markdown,
+		CreatedAt:   now,
+		NextAttempt: now,
+	}
+	msg.Hash = msg.digest()
+	msg.ID = fmt.Sprintf("%d-%s", now.UnixNano(), msg.Hash[:8])
+
+	o.mu.Lock()
+	if o.isDuplicate(cfg, msg.Hash, now) {
+		o.mu.Unlock()
+		log.Printf("[INFO] 通知渠道 %s 已有相同内容的通知，跳过重复发送: %s", ch.Name, title)
+		return nil
+	}
+	o.pending[msg.ID] = msg
+	o.persist(msg)
+	if wait, ok := o.acquire(ch, now); !ok {
+		msg.NextAttempt = now.Add(wait)
+		o.persist(msg)
+		o.mu.Unlock()
+		log.Printf("[WARN] 通知渠道 %s 触发限流（每分钟 %d 条），%v 后发送: %s", ch.Name, ch.RateLimitPerMinute, wait.Round(time.Second), title)
+		return nil
+	}
+	msg.inflight = true
+	o.mu.Unlock()
+
+	err := sendChannel(ch, title, markdown)
+	o.complete(cfg, msg, err)
+	if err != nil {
+		return fmt.Errorf("%w（已加入重试队列）", err)
+	}
+	return nil
+}
+
+// Run 定期重试到期的通知，并在待发送/发送失败数量变化时记录日志
+func (o *Outbox) Run(stopCh <-chan struct{}) {
+	if o.dir == "" {
+		return
+	}
+	ticker := time.NewTicker(outboxTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			if stats := o.Stats(); stats.Pending > 0 {
+				log.Printf("通知发件箱仍有 %d 条待发送，将在下次启动后继续发送", stats.Pending)
+			}
+			return
+		case <-ticker.C:
+			o.retryDue()
+			o.logStats()
+		}
+	}
+}
+
+// retryDue 发送所有到期的待发送通知
+func (o *Outbox) retryDue() {
+	cfg := o.provider.GetConfig()
+	if cfg == nil {
+		return
+	}
+	channels := map[string]entity.NotifierConfig{}
+	for _, ch := range cfg.NotificationChannels() {
+		channels[ch.Name] = ch
+	}
+
+	type job struct {
+		msg *outboxMessage
+		ch  entity.NotifierConfig
+	}
+	var jobs []job
+	now := time.Now()
+
+	o.mu.Lock()
+	for hash, t := range o.delivered {
+		if now.Sub(t) > cfg.Outbox.DedupWindow {
+			delete(o.delivered, hash)
+		}
+	}
+	for _, msg := range o.sortedPending() {
+		if msg.inflight || msg.NextAttempt.After(now) {
+			continue
+		}
+		ch, ok := channels[msg.Channel]
+		if !ok {
+			msg.LastError = "通知渠道不存在或未启用"
+			o.fail(msg)
+			continue
+		}
+		ch.AtMobiles = msg.AtMobiles
+		if wait, ok := o.acquire(ch, now); !ok {
+			msg.NextAttempt = now.Add(wait)
+			continue
+		}
+		msg.inflight = true
+		jobs = append(jobs, job{msg: msg, ch: ch})
+	}
+	o.mu.Unlock()
+
+	for _, j := range jobs {
+		err := sendChannel(j.ch, j.msg.Title, j.msg.Markdown)
+		if err == nil {
+			log.Printf("[INFO] 通知渠道 %s 重试发送成功（第 %d 次尝试）: %s", j.ch.Name, j.msg.Attempts+1, j.msg.Title)
+		}
+		o.complete(cfg, j.msg, err)
+	}
+}
+
+// complete 记录一次发送结果：成功则删除，失败则计算下次重试时间或标记为发送失败
+func (o *Outbox) complete(cfg *entity.Config, msg *outboxMessage, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	msg.inflight = false
+	msg.Attempts++
+	if err == nil {
+		delete(o.pending, msg.ID)
+		o.delivered[msg.Hash] = time.Now()
+		if rmErr := os.Remove(o.path(outboxPendingDir, msg.ID)); rmErr != nil && !os.IsNotExist(rmErr) {
+			log.Printf("[WARN] 删除已发送的通知文件失败: %v", rmErr)
+		}
+		return
+	}
+
+	msg.LastError = err.Error()
+	if msg.Attempts >= cfg.Outbox.MaxAttempts {
+		o.fail(msg)
+		return
+	}
+	delay := backoff(cfg.Outbox, msg.Attempts)
+	msg.NextAttempt = time.Now().Add(delay)
+	o.persist(msg)
+	log.Printf("[WARN] 通知渠道 %s 第 %d 次发送失败，%v 后重试: %v", msg.Channel, msg.Attempts, delay.Round(time.Second), err)
+}
+
+// fail 将通知移入 failed 目录，不再重试（调用方持有锁）
+func (o *Outbox) fail(msg *outboxMessage) {
+	delete(o.pending, msg.ID)
+	o.failed++
+	o.persist(msg)
+	if err := os.MkdirAll(filepath.Join(o.dir, outboxFailedDir), 0755); err == nil {
+		err = os.Rename(o.path(outboxPendingDir, msg.ID), o.path(outboxFailedDir, msg.ID))
+		if err != nil {
+			log.Printf("[WARN] 移动发送失败的通知文件失败: %v", err)
+		}
+	}
+	log.Printf("[ERROR] 通知渠道 %s 发送失败，已尝试 %d 次，不再重试: %s（%s）", msg.Channel, msg.Attempts, msg.Title, msg.LastError)
+}
+
+// isDuplicate 相同内容正在等待发送，或在去重窗口内已发送成功（调用方持有锁）
+func (o *Outbox) isDuplicate(cfg *entity.Config, hash string, now time.Time) bool {
+	if t, ok := o.delivered[hash]; ok && now.Sub(t) <= cfg.Outbox.DedupWindow {
+		return true
+	}
+	for _, m := range o.pending {
+		if m.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// acquire 按渠道限流占用一个发送名额，名额已满时返回需要等待的时长（调用方持有锁）
+func (o *Outbox) acquire(ch entity.NotifierConfig, now time.Time) (time.Duration, bool) {
+	if ch.RateLimitPerMinute <= 0 {
+		return 0, true
+	}
+	recent := o.sent[ch.Name][:0]
+	for _, t := range o.sent[ch.Name] {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= ch.RateLimitPerMinute {
+		o.sent[ch.Name] = recent
+		return recent[0].Add(time.Minute).Sub(now), false
+	}
+	o.sent[ch.Name] = append(recent, now)
+	return 0, true
+}
+
+// logStats 待发送/发送失败数量变化时记录日志
+func (o *Outbox) logStats() {
+	stats := o.Stats()
+	o.mu.Lock()
+	changed := stats != o.lastStats
+	o.lastStats = stats
+	o.mu.Unlock()
+	if changed {
+		log.Printf("通知发件箱: 待发送 %d 条，发送失败 %d 条", stats.Pending, stats.Failed)
+	}
+}
+
+// sortedPending 按创建时间排序的待发送通知，保证重试顺序与原始顺序一致（调用方持有锁）
+func (o *Outbox) sortedPending() []*outboxMessage {
+	list := make([]*outboxMessage, 0, len(o.pending))
+	for _, m := range o.pending {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+func (o *Outbox) path(sub, id string) string {
+	return filepath.Join(o.dir, sub, id+".json")
+}
+
+// persist 将通知写入 pending 目录（先写临时文件再重命名），写入失败只记录日志，通知仍保留在内存中重试
+func (o *Outbox) persist(msg *outboxMessage) {
+	if err := writeJSONFile(o.path(outboxPendingDir, msg.ID), msg); err != nil {
+		log.Printf("[ERROR] 保存待发送通知失败: %v", err)
+	}
+}
+
+// load 加载 pending 目录中的通知，并统计 failed 目录中的通知数量
+func (o *Outbox) load() error {
+	if err := os.MkdirAll(filepath.Join(o.dir, outboxPendingDir), 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(o.dir, outboxPendingDir))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(o.dir, outboxPendingDir, e.Name()))
+		if err != nil {
+			log.Printf("[WARN] 读取待发送通知 %s 失败: %v", e.Name(), err)
+			continue
+		}
+		var msg outboxMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.ID == "" {
+			log.Printf("[WARN] 待发送通知 %s 格式错误，已忽略", e.Name())
+			continue
+		}
+		o.pending[msg.ID] = &msg
+	}
+	if failed, err := os.ReadDir(filepath.Join(o.dir, outboxFailedDir)); err == nil {
+		for _, e := range failed {
+			if strings.HasSuffix(e.Name(), ".json") {
+				o.failed++
+			}
+		}
+	}
+	return nil
+}
+
+func (m *outboxMessage) digest() string {
+	h := sha256.New()
+	for _, part := range []string{m.Channel, m.Title, m.Markdown, strings.Join(m.AtMobiles, ",")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// backoff 第 attempts 次失败后的重试间隔：initial_backoff 每次翻倍，不超过 max_backoff，
+// 实际取 [间隔/2, 间隔] 之间的随机值，避免多条通知同时重试
+func backoff(cfg entity.OutboxConfig, attempts int) time.Duration {
+	d := cfg.InitialBackoff
+	for i := 1; i < attempts && d < cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > cfg.MaxBackoff {
+		d = cfg.MaxBackoff
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(d-half)+1))
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -4,152 +4,294 @@ import (
 	"GWatch/internal/domain/monitoring"
 	domainMonitor "GWatch/internal/domain/monitoring"
 	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 )
 
-// StatefulPolicy 实现防抖与连续计数策略
+// alertKey 防抖与连续计数的状态键：同一告警类型下不同对象（如 Redis 实例）分别计数
+type alertKey struct {
+	Type   entity.AlertType `json:"type"`
+	Target string           `json:"target,omitempty"`
+}
+
+func keyOf(d domainMonitor.Decision) alertKey { return alertKey{Type: d.Type, Target: d.Target} }
+
+func (k alertKey) String() string { return k.Type.Describe(k.Target) }
+
+// incident 一次已发出告警的生命周期：从首次发出到恢复
+type incident struct {
+	record        *entity.ScheduledPushAlertRecord
+	peak          float64
+	healthy       int       // 连续未命中的周期数
+	criticalSince time.Time // 本次连续处于 critical 级别的开始时间，非 critical 时为零值
+}
+
+// StatefulPolicy 实现防抖、连续计数与恢复判断策略
 type StatefulPolicy struct {
 	mu        sync.RWMutex
-	counters  map[entity.AlertType]int
-	lastTimes map[entity.AlertType]time.Time
+	counters  map[alertKey]int
+	lastTimes map[alertKey]time.Time
+	incidents map[alertKey]*incident // 已发出且尚未恢复的告警
+
+	flaps       map[alertKey]*flapState // 抖动检测的状态切换跟踪
+	flapHistory []*entity.FlapRecord    // 抖动记录，供定时报告查询
 }
 
 func NewStatefulPolicy() monitoring.Policy {
-	return &StatefulPolicy{counters: map[entity.AlertType]int{}, lastTimes: map[entity.AlertType]time.Time{}}
+	return &StatefulPolicy{counters: map[alertKey]int{}, lastTimes: map[alertKey]time.Time{}, incidents: map[alertKey]*incident{}, flaps: map[alertKey]*flapState{}}
 }
 
-func (p *StatefulPolicy) Apply(cfg *entity.Config, _ *entity.SystemMetrics, decisions []domainMonitor.Decision) []entity.AlertType {
-	now := time.Now()
-	var result []entity.AlertType
-	// 累加连续计数并应用防抖
+// alertSettings 单个告警（类型 + 对象）生效的策略参数
+type alertSettings struct {
+	consecutive    int           // 连续多少个周期命中才触发
+	repeatInterval time.Duration // 告警未恢复时重复发送的最小间隔
+	recovery       int           // 连续多少个周期未命中视为恢复
+}
+
+// settingsFor 取告警的策略参数：默认值沿用 host_monitoring（HTTP 的重复间隔为 http.interval），再由第一个命中的 alert_policies 覆盖
+func settingsFor(cfg *entity.Config, k alertKey) alertSettings {
+	s := alertSettings{consecutive: 1, repeatInterval: 2 * time.Minute, recovery: 3} // 未配置 host_monitoring 时的默认值
+	if h := cfg.HostMonitoring; h != nil {
+		s.repeatInterval = h.AlertInterval
+		if h.RecoveryThreshold > 0 {
+			s.recovery = h.RecoveryThreshold
+		}
+	}
+	if entity.AlertTypeRequiresConsecutive[k.Type] {
+		s.consecutive = 3
+		if cfg.HostMonitoring != nil {
+			s.consecutive = cfg.HostMonitoring.ConsecutiveThreshold
+		}
+	}
+	// HTTP使用专用间隔
+	if k.Type == entity.HTTPErr && cfg.AppMonitoring != nil && cfg.AppMonitoring.HTTP != nil {
+		s.repeatInterval = cfg.AppMonitoring.HTTP.Interval
+	}
+	for _, ap := range cfg.AlertPolicies {
+		if len(ap.AlertTypes) > 0 && !utils.MatchAny(ap.AlertTypes, string(k.Type)) ||
+			len(ap.Targets) > 0 && !utils.MatchAny(ap.Targets, k.Target) {
+			continue
+		}
+		if ap.ConsecutiveThreshold > 0 {
+			s.consecutive = ap.ConsecutiveThreshold
+		}
+		if ap.RepeatInterval > 0 {
+			s.repeatInterval = ap.RepeatInterval
+		}
+		if ap.RecoveryThreshold > 0 {
+			s.recovery = ap.RecoveryThreshold
+		}
+		break
+	}
+	if s.consecutive < 1 {
+		s.consecutive = 1
+	}
+	return s
+}
+
+func (p *StatefulPolicy) Apply(cfg *entity.Config, _ *entity.SystemMetrics, decisions []domainMonitor.Decision) []domainMonitor.Decision {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.apply(cfg, decisions, true)
+}
+
+// PeekApply 在不修改内部状态的前提下，根据当前 decisions 预判会触发的告警
+func (p *StatefulPolicy) PeekApply(cfg *entity.Config, _ *entity.SystemMetrics, decisions []domainMonitor.Decision) []domainMonitor.Decision {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.apply(cfg, decisions, false)
+}
 
-	// 首先将未命中的连续型类型计数清零
-	consecutiveSet := map[entity.AlertType]bool{entity.CPUHigh: true, entity.MemHigh: true, entity.HTTPErr: true}
-	hit := map[entity.AlertType]bool{}
-	for _, d := range decisions {
-		hit[d.Type] = true
+// ApplyMerged 另一周期合并通知时代本策略发出告警：按当前状态预判会触发的告警，并在同一把锁内记录为已发出
+// （更新防抖时间、新建告警事件），之后由本策略所属周期负责恢复，不会再作为新告警重复发出；
+// 连续计数与抖动跟踪只由所属周期的 Apply 累加，不受合并通知的频率影响
+func (p *StatefulPolicy) ApplyMerged(cfg *entity.Config, _ *entity.SystemMetrics, decisions []domainMonitor.Decision) []domainMonitor.Decision {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	alerts := p.apply(cfg, decisions, false)
+	now := time.Now()
+	for _, d := range alerts {
+		k := keyOf(d)
+		p.fire(k, d, now)
+		if d.Escalated {
+			p.incidents[k].record.EscalatedAt = &now
+		}
+		log.Printf("[WARN] %s 告警触发（合并通知）", k)
 	}
-	for t := range consecutiveSet {
-		if !hit[t] {
-			p.counters[t] = 0
+	return alerts
+}
+
+// apply 累加连续计数并应用防抖，返回本周期需要通知的告警；commit 为 false 时只预判，不修改任何状态
+// 调用方需持有 p.mu
+func (p *StatefulPolicy) apply(cfg *entity.Config, decisions []domainMonitor.Decision, commit bool) []domainMonitor.Decision {
+	now := time.Now()
+	var result []domainMonitor.Decision
+
+	counters := p.counters
+	if !commit {
+		counters = make(map[alertKey]int, len(p.counters))
+		for k, v := range p.counters {
+			counters[k] = v
 		}
 	}
 
+	// 首先将未命中的连续计数清零
+	hit := map[alertKey]bool{}
 	for _, d := range decisions {
-		t := d.Type
-		// 针对不同类型选择防抖间隔：HTTP使用专有 http_interval，其他使用通用 alert_interval
-		var usedInterval time.Duration
-		var consecutiveThreshold int
-		
-		if cfg.HostMonitoring != nil {
-			usedInterval = cfg.HostMonitoring.AlertInterval
-			consecutiveThreshold = cfg.HostMonitoring.ConsecutiveThreshold
-		} else {
-			usedInterval = 2 * time.Minute // 默认间隔
-			consecutiveThreshold = 3       // 默认阈值
+		hit[keyOf(d)] = true
+	}
+	for k := range counters {
+		if !hit[k] {
+			counters[k] = 0
 		}
-		
-		// HTTP使用专用间隔
-		if t == entity.HTTPErr && cfg.AppMonitoring != nil && cfg.AppMonitoring.HTTP != nil {
-			usedInterval = cfg.AppMonitoring.HTTP.Interval
+	}
+	var notices []domainMonitor.Decision
+	if commit {
+		for k, inc := range p.incidents {
+			if !hit[k] {
+				inc.criticalSince = time.Time{}
+			}
 		}
+		notices = p.trackFlapping(cfg, decisions, now)
+	}
 
-		// 连续计数
-		if consecutiveSet[t] {
-			p.counters[t] = p.counters[t] + 1
-		} else {
-			p.counters[t] = 1
+	for _, d := range decisions {
+		k := keyOf(d)
+		s := settingsFor(cfg, k)
+		counters[k]++
+
+		// 抖动期间暂停通知，抖动通知由 trackFlapping 单独生成
+		if p.flapping(k) {
+			continue
+		}
+
+		// 告警期间记录最严重的观测值，并跟踪持续处于 critical 的时间
+		inc, firing := p.incidents[k]
+		upgraded := false
+		if firing {
+			criticalSince := inc.criticalSince
+			if d.Severity != entity.SeverityCritical {
+				criticalSince = time.Time{}
+			} else if criticalSince.IsZero() {
+				criticalSince = now
+			}
+			if commit {
+				inc.peak = k.Type.Worse(inc.peak, d.Value)
+				inc.criticalSince = criticalSince
+			}
+			upgraded = entity.MoreSevere(d.Severity, inc.record.Severity)
+			// critical 持续超过 escalation.after 且未被静默（确认）时升级，每个告警事件只升级一次
+			if esc := cfg.Escalation; esc != nil && esc.After > 0 && inc.record.EscalatedAt == nil && inc.record.SilencedBy == "" &&
+				!criticalSince.IsZero() && now.Sub(criticalSince) >= esc.After {
+				if commit {
+					inc.record.EscalatedAt = &now
+					p.lastTimes[k] = now
+					log.Printf("[WARN] %s 持续 critical 超过 %v 未恢复，告警升级", k, esc.After)
+				}
+				d.Escalated = true
+				result = append(result, d)
+				continue
+			}
+		}
+		// 防抖：间隔未到不触发；告警级别升高（如 warning 升为 critical）时立即通知
+		last, ok := p.lastTimes[k]
+		if ok && now.Sub(last) < s.repeatInterval && !upgraded {
+			continue
 		}
-		// 防抖：间隔未到不触发
-		last, ok := p.lastTimes[t]
-		if ok && now.Sub(last) < usedInterval {
+		// 达到连续触发次数阈值才触发
+		if counters[k] < s.consecutive {
+			if commit {
+				log.Printf("[INFO] %s 连续第 %d/%d 次超阈值，暂不告警", k, counters[k], s.consecutive)
+			}
 			continue
 		}
-		// 连续型达到连续触发次数阈值才触发
-		if consecutiveSet[t] && p.counters[t] < consecutiveThreshold {
-			log.Printf("[INFO] %s 连续第 %d/%d 次超阈值，暂不告警", t.String(), p.counters[t], consecutiveThreshold)
+		result = append(result, d)
+		if !commit {
 			continue
 		}
 		// 触发
-		p.lastTimes[t] = now
-		if consecutiveSet[t] {
-			log.Printf("[WARN] %s 连续第 %d 次超阈值，告警已触发", t.String(), p.counters[t])
+		p.fire(k, d, now)
+		if s.consecutive > 1 {
+			log.Printf("[WARN] %s 连续第 %d 次超阈值，告警已触发", k, counters[k])
 		} else {
-			log.Printf("[WARN] %s 告警触发", t.String())
+			log.Printf("[WARN] %s 告警触发", k)
 		}
-		result = append(result, t)
+	}
+	// 抖动通知同样作为一次发出的告警，状态稳定后正常恢复
+	for _, d := range notices {
+		p.fire(keyOf(d), d, now)
+		result = append(result, d)
 	}
 	return result
 }
 
-// PeekApply 在不修改内部状态的前提下，根据当前 decisions 预判会触发的告警类型
-func (p *StatefulPolicy) PeekApply(cfg *entity.Config, _ *entity.SystemMetrics, decisions []domainMonitor.Decision) []entity.AlertType {
-    now := time.Now()
-    var result []entity.AlertType
-
-    consecutiveSet := map[entity.AlertType]bool{entity.CPUHigh: true, entity.MemHigh: true, entity.HTTPErr: true}
-
-    p.mu.RLock()
-    // 拷贝当前状态
-    countersCopy := make(map[entity.AlertType]int, len(p.counters))
-    for k, v := range p.counters {
-        countersCopy[k] = v
-    }
-    lastTimesCopy := make(map[entity.AlertType]time.Time, len(p.lastTimes))
-    for k, v := range p.lastTimes {
-        lastTimesCopy[k] = v
-    }
-    p.mu.RUnlock()
-
-    hit := map[entity.AlertType]bool{}
-    for _, d := range decisions {
-        hit[d.Type] = true
-    }
-    // 先清零未命中的连续型计数（模拟）
-    for t := range consecutiveSet {
-        if !hit[t] {
-            countersCopy[t] = 0
-        }
-    }
-
-    for _, d := range decisions {
-        t := d.Type
-        // 连续计数（模拟）
-        if consecutiveSet[t] {
-            countersCopy[t] = countersCopy[t] + 1
-        } else {
-            countersCopy[t] = 1
-        }
-        // 防抖：间隔未到则不会触发
-        last, ok := lastTimesCopy[t]
-        var usedInterval time.Duration
-        var consecutiveThreshold int
-		
-		if cfg.HostMonitoring != nil {
-			usedInterval = cfg.HostMonitoring.AlertInterval
-			consecutiveThreshold = cfg.HostMonitoring.ConsecutiveThreshold
-		} else {
-			usedInterval = 2 * time.Minute // 默认间隔
-			consecutiveThreshold = 3       // 默认阈值
-		}
-		
-		// HTTP使用专用间隔
-		if t == entity.HTTPErr && cfg.AppMonitoring != nil && cfg.AppMonitoring.HTTP != nil {
-			usedInterval = cfg.AppMonitoring.HTTP.Interval
-		}
-		
-        if ok && now.Sub(last) < usedInterval {
-            continue
-        }
-        // 连续触发阈值判断
-        if consecutiveSet[t] && countersCopy[t] < consecutiveThreshold {
-            continue
-        }
-        result = append(result, t)
-    }
-    return result
+// fire 记录告警已发出：更新防抖时间，未在告警中时新建告警事件；调用方需持有 p.mu
+func (p *StatefulPolicy) fire(k alertKey, d domainMonitor.Decision, now time.Time) {
+	p.lastTimes[k] = now
+	inc, firing := p.incidents[k]
+	if !firing {
+		id := fmt.Sprintf("%s:%s:%d", k.Type, k.Target, now.Unix())
+		inc = &incident{record: entity.NewMonitoringAlertRecord(id, k.String(), k.String(), now), peak: d.Value}
+		if d.Severity == entity.SeverityCritical {
+			inc.criticalSince = now
+		}
+		p.incidents[k] = inc
+	}
+	if entity.MoreSevere(d.Severity, inc.record.Severity) {
+		inc.record.Severity = d.Severity
+	}
+}
+
+// Resolve 对已发出的告警累计未命中周期数，达到 recovery_threshold 后标记记录已解决并返回恢复事件；抖动期间不恢复
+// 已恢复的告警清除防抖时间，再次超标时按新告警处理
+func (p *StatefulPolicy) Resolve(cfg *entity.Config, decisions []domainMonitor.Decision) []domainMonitor.Recovery {
+	hit := map[alertKey]bool{}
+	for _, d := range decisions {
+		hit[keyOf(d)] = true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var result []domainMonitor.Recovery
+	for k, inc := range p.incidents {
+		if hit[k] {
+			inc.healthy = 0
+			continue
+		}
+		inc.healthy++
+		if inc.healthy < settingsFor(cfg, k).recovery || p.flapping(k) {
+			continue
+		}
+		inc.record.Resolve()
+		delete(p.incidents, k)
+		delete(p.lastTimes, k)
+		log.Printf("[INFO] %s 连续 %d 个周期未超阈值，告警已恢复，持续 %v", k, inc.healthy, inc.record.Duration().Round(time.Second))
+		result = append(result, domainMonitor.Recovery{Type: k.Type, Target: k.Target, Peak: inc.peak, Record: inc.record})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Record.Timestamp.Before(result[j].Record.Timestamp) })
+	return result
+}
+
+// Discard 撤销本次发出时新建的告警事件；之前已发送过的事件保持不变
+func (p *StatefulPolicy) Discard(d domainMonitor.Decision) {
+	k := keyOf(d)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if inc, ok := p.incidents[k]; ok && inc.record.Timestamp.Equal(p.lastTimes[k]) {
+		delete(p.incidents, k)
+	}
+}
+
+// MarkSilenced 记录告警事件最近一次发出时命中的静默，空字符串表示已正常发送
+func (p *StatefulPolicy) MarkSilenced(d domainMonitor.Decision, silenceID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if inc, ok := p.incidents[keyOf(d)]; ok {
+		inc.record.SilencedBy = silenceID
+	}
 }
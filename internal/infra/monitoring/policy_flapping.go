@@ -0,0 +1,129 @@
+package monitoring
+
+import (
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// flapHistoryRetention 已解除的抖动记录保留时长，供定时报告查询
+const flapHistoryRetention = 7 * 24 * time.Hour
+
+// flapState 单个告警（类型 + 对象）的状态切换跟踪
+type flapState struct {
+	hit         bool               // 最近一个周期是否命中
+	transitions []time.Time        // flapping.window 内命中与未命中之间的切换时间
+	lastChange  time.Time          // 最近一次切换的时间
+	record      *entity.FlapRecord // 正在抖动时的记录，未抖动为 nil
+}
+
+// flapping 告警是否正在抖动；抖动期间暂停该告警的通知与恢复通知
+func (p *StatefulPolicy) flapping(k alertKey) bool {
+	st, ok := p.flaps[k]
+	return ok && st.record != nil
+}
+
+// trackFlapping 记录本周期各告警的状态切换，返回新判定为抖动的告警（每次抖动只通知一次）；状态稳定 stable_for 后解除抖动
+// 调用方需持有 p.mu
+func (p *StatefulPolicy) trackFlapping(cfg *entity.Config, decisions []domainMonitor.Decision, now time.Time) []domainMonitor.Decision {
+	f := cfg.Flapping
+	if f == nil {
+		for k, st := range p.flaps {
+			if st.record != nil {
+				p.endFlapping(k, st, now)
+			}
+		}
+		p.flaps = map[alertKey]*flapState{}
+		return nil
+	}
+
+	current := map[alertKey]domainMonitor.Decision{}
+	for _, d := range decisions {
+		k := keyOf(d)
+		current[k] = d
+		if p.flaps[k] == nil {
+			p.flaps[k] = &flapState{}
+		}
+	}
+
+	var notices []domainMonitor.Decision
+	for k, st := range p.flaps {
+		d, hit := current[k]
+		if hit != st.hit {
+			st.hit = hit
+			st.lastChange = now
+			st.transitions = append(st.transitions, now)
+			if st.record != nil {
+				st.record.Transitions++
+			}
+		}
+		i := 0
+		for i < len(st.transitions) && now.Sub(st.transitions[i]) > f.Window {
+			i++
+		}
+		st.transitions = st.transitions[i:]
+
+		switch {
+		case st.record == nil && len(st.transitions) >= f.Threshold:
+			st.record = &entity.FlapRecord{Type: k.Type, Target: k.Target, Start: now, Transitions: len(st.transitions)}
+			p.flapHistory = append(p.flapHistory, st.record)
+			log.Printf("[WARN] %s 在 %v 内状态切换 %d 次，判定为抖动，暂停通知直到状态稳定 %v", k, f.Window, len(st.transitions), f.StableFor)
+			if !hit {
+				d = domainMonitor.Decision{Type: k.Type, Target: k.Target, Severity: entity.SeverityWarning}
+			}
+			d.Flapping, d.Escalated = true, false
+			d.Message = fmt.Sprintf("%s 状态频繁变化（%v 内切换 %d 次），暂停该告警的通知，状态稳定 %v 后恢复", k, f.Window, len(st.transitions), f.StableFor)
+			notices = append(notices, d)
+		case st.record != nil && now.Sub(st.lastChange) >= f.StableFor:
+			p.endFlapping(k, st, now)
+		}
+		if st.record == nil && !st.hit && len(st.transitions) == 0 {
+			delete(p.flaps, k)
+		}
+	}
+
+	kept := p.flapHistory[:0]
+	for _, r := range p.flapHistory {
+		if r.Active() || now.Sub(r.End) < flapHistoryRetention {
+			kept = append(kept, r)
+		}
+	}
+	p.flapHistory = kept
+	return notices
+}
+
+// endFlapping 解除抖动，之后按正常策略通知
+func (p *StatefulPolicy) endFlapping(k alertKey, st *flapState, now time.Time) {
+	st.record.End = now
+	log.Printf("[INFO] %s 状态已稳定，解除抖动（抖动期间切换 %d 次）", k, st.record.Transitions)
+	st.record = nil
+	st.transitions = nil
+}
+
+// Flapped 返回 since 之后处于抖动状态的告警，按开始时间排序
+func (p *StatefulPolicy) Flapped(since time.Time) []entity.FlapRecord {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var result []entity.FlapRecord
+	for _, r := range p.flapHistory {
+		if r.OverlapsSince(since) {
+			result = append(result, *r)
+		}
+	}
+	return result
+}
+
+// FlapHistories 合并多个告警策略（基础与 HTTP）的抖动记录
+type FlapHistories []*StatefulPolicy
+
+func (h FlapHistories) Flapped(since time.Time) []entity.FlapRecord {
+	var result []entity.FlapRecord
+	for _, p := range h {
+		result = append(result, p.Flapped(since)...)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
@@ -0,0 +1,219 @@
+package monitoring
+
+import (
+	"GWatch/internal/domain/config"
+	"GWatch/internal/entity"
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+)
+
+// policyStateTick 未配置 policy_state.save_interval 时的保存间隔
+const policyStateTick = 30 * time.Second
+
+// policyStateFile 状态文件内容：按名称保存的各告警策略状态
+type policyStateFile struct {
+	SavedAt  time.Time              `json:"saved_at"`
+	Policies map[string]policyState `json:"policies"`
+}
+
+// policyState 单个告警策略的状态
+type policyState struct {
+	Counters  []counterState  `json:"counters,omitempty"`
+	LastTimes []lastTimeState `json:"last_times,omitempty"`
+	Incidents []incidentState `json:"incidents,omitempty"`
+
+	Flaps       []flapSnapshot      `json:"flaps,omitempty"`
+	FlapHistory []entity.FlapRecord `json:"flap_history,omitempty"`
+}
+
+type counterState struct {
+	alertKey
+	Count int `json:"count"`
+}
+
+type lastTimeState struct {
+	alertKey
+	Time time.Time `json:"time"`
+}
+
+type incidentState struct {
+	alertKey
+	Record        *entity.ScheduledPushAlertRecord `json:"record"`
+	Peak          float64                          `json:"peak"`
+	Healthy       int                              `json:"healthy,omitempty"`
+	CriticalSince time.Time                        `json:"critical_since,omitempty"`
+}
+
+type flapSnapshot struct {
+	alertKey
+	Hit         bool        `json:"hit,omitempty"`
+	Transitions []time.Time `json:"transitions,omitempty"`
+	LastChange  time.Time   `json:"last_change"`
+	Flapping    bool        `json:"flapping,omitempty"` // 正在抖动，对应 FlapHistory 中同一告警未解除的记录
+}
+
+// snapshot 复制当前状态，计数为 0 的连续计数不保存
+func (p *StatefulPolicy) snapshot() policyState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var st policyState
+	for k, n := range p.counters {
+		if n > 0 {
+			st.Counters = append(st.Counters, counterState{alertKey: k, Count: n})
+		}
+	}
+	for k, t := range p.lastTimes {
+		st.LastTimes = append(st.LastTimes, lastTimeState{alertKey: k, Time: t})
+	}
+	for k, inc := range p.incidents {
+		record := *inc.record
+		st.Incidents = append(st.Incidents, incidentState{alertKey: k, Record: &record, Peak: inc.peak, Healthy: inc.healthy, CriticalSince: inc.criticalSince})
+	}
+	for k, f := range p.flaps {
+		st.Flaps = append(st.Flaps, flapSnapshot{alertKey: k, Hit: f.hit, Transitions: append([]time.Time(nil), f.transitions...), LastChange: f.lastChange, Flapping: f.record != nil})
+	}
+	for _, r := range p.flapHistory {
+		st.FlapHistory = append(st.FlapHistory, *r)
+	}
+	return st
+}
+
+// restore 用保存的状态替换当前状态，keepCounters 为 false 时连续计数从 0 开始；返回恢复的未恢复告警数
+func (p *StatefulPolicy) restore(st policyState, keepCounters bool) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counters = map[alertKey]int{}
+	p.lastTimes = map[alertKey]time.Time{}
+	p.incidents = map[alertKey]*incident{}
+	p.flaps = map[alertKey]*flapState{}
+	p.flapHistory = nil
+	if keepCounters {
+		for _, c := range st.Counters {
+			p.counters[c.alertKey] = c.Count
+		}
+	}
+	for _, t := range st.LastTimes {
+		p.lastTimes[t.alertKey] = t.Time
+	}
+	for _, inc := range st.Incidents {
+		if inc.Record == nil || inc.Record.IsResolved {
+			continue
+		}
+		p.incidents[inc.alertKey] = &incident{record: inc.Record, peak: inc.Peak, healthy: inc.Healthy, criticalSince: inc.CriticalSince}
+	}
+	active := map[alertKey]*entity.FlapRecord{}
+	for i := range st.FlapHistory {
+		r := st.FlapHistory[i]
+		p.flapHistory = append(p.flapHistory, &r)
+		if r.Active() {
+			active[alertKey{Type: r.Type, Target: r.Target}] = &r
+		}
+	}
+	for _, f := range st.Flaps {
+		fs := &flapState{hit: f.Hit, transitions: f.Transitions, lastChange: f.LastChange}
+		if f.Flapping {
+			fs.record = active[f.alertKey]
+		}
+		p.flaps[f.alertKey] = fs
+	}
+	return len(p.incidents)
+}
+
+// PolicyStateStore 将基础与 HTTP 告警策略的状态保存到 policy_state.file，重启（如滚动发布）后恢复，
+// 未恢复的告警按原防抖时间继续，不会在启动后立即重复发送
+type PolicyStateStore struct {
+	provider config.Provider
+	policies map[string]*StatefulPolicy
+}
+
+func NewPolicyStateStore(provider config.Provider, base, http *StatefulPolicy) *PolicyStateStore {
+	return &PolicyStateStore{provider: provider, policies: map[string]*StatefulPolicy{"base": base, "http": http}}
+}
+
+// Restore 读取状态文件：文件保存时间超过 max_age 时整体丢弃；
+// 停机超过两个检查周期时连续计数已不再连续，只恢复最近发送时间与未恢复的告警
+func (s *PolicyStateStore) Restore() {
+	cfg := s.provider.GetConfig()
+	if cfg == nil || !cfg.PolicyState.IsEnabled() {
+		return
+	}
+	path := cfg.PolicyState.File
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR] 读取告警策略状态文件 %s 失败: %v", path, err)
+		return
+	}
+	var f policyStateFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		log.Printf("[ERROR] 解析告警策略状态文件 %s 失败，忽略: %v", path, err)
+		return
+	}
+	age := time.Since(f.SavedAt)
+	if age > cfg.PolicyState.MaxAge {
+		log.Printf("[WARN] 告警策略状态文件 %s 保存于 %v 前，超过 max_age (%v)，不恢复", path, age.Round(time.Second), cfg.PolicyState.MaxAge)
+		return
+	}
+	incidents := 0
+	for name, p := range s.policies {
+		st, ok := f.Policies[name]
+		if !ok {
+			continue
+		}
+		incidents += p.restore(st, age <= 2*checkInterval(cfg, name))
+	}
+	log.Printf("已恢复告警策略状态（保存于 %v 前），未恢复的告警 %d 个", age.Round(time.Second), incidents)
+}
+
+// checkInterval 告警策略对应的检查周期
+func checkInterval(cfg *entity.Config, name string) time.Duration {
+	if name == "http" {
+		return cfg.HTTPInterval()
+	}
+	return cfg.BaseInterval()
+}
+
+// Run 按 policy_state.save_interval 定期保存，直到 stopCh 关闭；退出前的保存由调用方在监控循环结束后调用 Save
+func (s *PolicyStateStore) Run(stopCh <-chan struct{}) {
+	interval := s.saveInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := s.Save(); err != nil {
+				log.Printf("[ERROR] 保存告警策略状态失败: %v", err)
+			}
+			if next := s.saveInterval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (s *PolicyStateStore) saveInterval() time.Duration {
+	if cfg := s.provider.GetConfig(); cfg != nil && cfg.PolicyState.SaveInterval > 0 {
+		return cfg.PolicyState.SaveInterval
+	}
+	return policyStateTick
+}
+
+// Save 将各告警策略的当前状态写入状态文件，未启用时不做任何事
+func (s *PolicyStateStore) Save() error {
+	cfg := s.provider.GetConfig()
+	if cfg == nil || !cfg.PolicyState.IsEnabled() {
+		return nil
+	}
+	f := policyStateFile{SavedAt: time.Now(), Policies: map[string]policyState{}}
+	for name, p := range s.policies {
+		f.Policies[name] = p.snapshot()
+	}
+	return writeJSONFile(cfg.PolicyState.File, f)
+}
@@ -0,0 +1,102 @@
+package monitoring
+
+import (
+	domainMonitor "GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"GWatch/internal/infra/monitoring/rules"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// RuleEvaluator 在内置阈值判断之外按配置的 rules 表达式规则判断
+// 表达式持续成立 for 时长后产生决策：类型为 rule，对象为规则名称，消息由规则模板渲染
+type RuleEvaluator struct {
+	builtin domainMonitor.Evaluator
+
+	mu       sync.Mutex
+	compiled map[string]compiledRule // 规则名称 -> 编译结果，配置变化后重新编译
+	pending  map[string]time.Time    // 规则名称 -> 表达式本次开始成立的时间
+}
+
+type compiledRule struct {
+	config entity.AlertRule
+	rule   *rules.Rule
+}
+
+// NewRuleEvaluator 创建规则评估器，builtin 为内置阈值判断
+func NewRuleEvaluator(builtin domainMonitor.Evaluator) *RuleEvaluator {
+	return &RuleEvaluator{builtin: builtin, compiled: map[string]compiledRule{}, pending: map[string]time.Time{}}
+}
+
+func (e *RuleEvaluator) Evaluate(cfg *entity.Config, metrics *entity.SystemMetrics) ([]domainMonitor.Decision, error) {
+	var decisions []domainMonitor.Decision
+	if !cfg.Rules.DisableBuiltin {
+		var err error
+		if decisions, err = e.builtin.Evaluate(cfg, metrics); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	configured := map[string]bool{}
+	for _, ar := range cfg.Rules.Alerts {
+		configured[ar.Name] = true
+		rule := e.rule(ar)
+		if rule == nil {
+			continue
+		}
+		matched, err := rule.Match(metrics)
+		if err != nil && !errors.Is(err, rules.ErrNoData) {
+			log.Printf("[ERROR] 规则 %s 求值失败: %v", ar.Name, err)
+		}
+		if !matched {
+			delete(e.pending, ar.Name)
+			continue
+		}
+		since, ok := e.pending[ar.Name]
+		if !ok {
+			since = now
+			e.pending[ar.Name] = now
+		}
+		if now.Sub(since) < ar.For {
+			continue
+		}
+		message, err := rule.Message(metrics)
+		if err != nil {
+			log.Printf("[ERROR] 规则 %s 消息模板渲染失败: %v", ar.Name, err)
+		}
+		severity := ar.Severity
+		if severity == "" {
+			severity = entity.SeverityWarning
+		}
+		decisions = append(decisions, domainMonitor.Decision{Type: entity.RuleAlert, Target: ar.Name, Severity: severity, Message: message})
+	}
+	// 清理已从配置中删除的规则
+	for name := range e.compiled {
+		if !configured[name] {
+			delete(e.compiled, name)
+			delete(e.pending, name)
+		}
+	}
+	return decisions, nil
+}
+
+// rule 取规则的编译结果，配置变化时重新编译；编译失败只记录一次日志（配置校验已在加载时拦截）
+func (e *RuleEvaluator) rule(ar entity.AlertRule) *rules.Rule {
+	if c, ok := e.compiled[ar.Name]; ok && c.config == ar {
+		return c.rule
+	}
+	rule, err := rules.CompileRule(ar)
+	if err != nil {
+		log.Printf("[ERROR] 规则 %s 编译失败: %v", ar.Name, err)
+	}
+	if c, ok := e.compiled[ar.Name]; ok && c.config.Expr != ar.Expr {
+		delete(e.pending, ar.Name)
+	}
+	e.compiled[ar.Name] = compiledRule{config: ar, rule: rule}
+	return rule
+}
@@ -0,0 +1,394 @@
+package rules
+
+import (
+	"GWatch/internal/entity"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Type 表达式的值类型
+type Type int
+
+const (
+	typeObject Type = iota // 对象或集合字段，不能直接参与运算
+	TypeNumber
+	TypeBool
+	TypeString
+	TypeDuration
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeNumber:
+		return "number"
+	case TypeBool:
+		return "bool"
+	case TypeString:
+		return "string"
+	case TypeDuration:
+		return "duration"
+	default:
+		return "object"
+	}
+}
+
+// ErrNoData 指标当前没有数据（采集失败、实例/接口不存在等），此时规则视为不成立
+var ErrNoData = errors.New("指标无数据")
+
+// Program 已通过类型检查的表达式
+type Program struct {
+	src  string
+	root node
+	typ  Type
+}
+
+// Compile 解析表达式并做类型检查：字段必须存在，运算两侧类型必须匹配
+func Compile(src string) (*Program, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	c := &checker{src: src}
+	typ, err := c.check(root)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{src: src, root: root, typ: typ}, nil
+}
+
+// Type 表达式结果的类型
+func (p *Program) Type() Type { return p.typ }
+
+func (p *Program) String() string { return p.src }
+
+// Eval 按一次采集的指标求值，结果为 float64/bool/string/time.Duration；引用的指标没有数据时返回 ErrNoData
+func (p *Program) Eval(m *entity.SystemMetrics) (interface{}, error) {
+	return p.root.eval(m)
+}
+
+// node 语法树节点
+type node interface {
+	position() int
+	eval(m *entity.SystemMetrics) (interface{}, error)
+}
+
+type literal struct {
+	pos   int
+	typ   Type
+	value interface{}
+}
+
+type step struct {
+	name string
+	key  *string // ["name"] 索引，未指定时为 nil
+}
+
+type pathExpr struct {
+	pos    int
+	steps  []step
+	fields []*field // 类型检查时解析出的各级字段
+}
+
+type unaryExpr struct {
+	pos int
+	op  string
+	x   node
+}
+
+type binaryExpr struct {
+	pos         int
+	op          string
+	left, right node
+}
+
+func (n *literal) position() int    { return n.pos }
+func (n *pathExpr) position() int   { return n.pos }
+func (n *unaryExpr) position() int  { return n.pos }
+func (n *binaryExpr) position() int { return n.pos }
+
+// checker 类型检查
+type checker struct {
+	src string
+}
+
+func (c *checker) errorf(n node, format string, args ...interface{}) error {
+	return syntaxError(c.src, n.position(), format, args...)
+}
+
+func (c *checker) check(n node) (Type, error) {
+	switch n := n.(type) {
+	case *literal:
+		return n.typ, nil
+	case *pathExpr:
+		return c.checkPath(n)
+	case *unaryExpr:
+		t, err := c.check(n.x)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case n.op == "!" && t == TypeBool, n.op == "-" && (t == TypeNumber || t == TypeDuration):
+			return t, nil
+		}
+		return 0, c.errorf(n, "%q 不能用于 %s", n.op, t)
+	case *binaryExpr:
+		return c.checkBinary(n)
+	}
+	return 0, fmt.Errorf("未知的表达式节点 %T", n)
+}
+
+func (c *checker) checkBinary(n *binaryExpr) (Type, error) {
+	l, err := c.check(n.left)
+	if err != nil {
+		return 0, err
+	}
+	r, err := c.check(n.right)
+	if err != nil {
+		return 0, err
+	}
+	mismatch := func() (Type, error) {
+		hint := ""
+		if l == TypeDuration && r == TypeNumber || l == TypeNumber && r == TypeDuration {
+			hint = "，时长请带单位，如 2s、500ms"
+		}
+		return 0, c.errorf(n, "%q 两侧类型不匹配: %s %s %s%s", n.op, l, n.op, r, hint)
+	}
+	switch n.op {
+	case "&&", "||":
+		if l != TypeBool || r != TypeBool {
+			return 0, c.errorf(n, "%q 两侧应为 bool，实际为 %s 与 %s", n.op, l, r)
+		}
+		return TypeBool, nil
+	case "==", "!=":
+		if l != r {
+			return mismatch()
+		}
+		return TypeBool, nil
+	case "<", "<=", ">", ">=":
+		if l != r {
+			return mismatch()
+		}
+		if l == TypeBool {
+			return 0, c.errorf(n, "%q 不能用于 bool", n.op)
+		}
+		return TypeBool, nil
+	case "+", "-":
+		if l != r {
+			return mismatch()
+		}
+		if l != TypeNumber && l != TypeDuration {
+			return 0, c.errorf(n, "%q 不能用于 %s", n.op, l)
+		}
+		return l, nil
+	case "*":
+		switch {
+		case l == TypeNumber && r == TypeNumber:
+			return TypeNumber, nil
+		case l == TypeDuration && r == TypeNumber:
+			return TypeDuration, nil
+		}
+		return mismatch()
+	case "/":
+		switch {
+		case l == TypeNumber && r == TypeNumber, l == TypeDuration && r == TypeDuration:
+			return TypeNumber, nil
+		case l == TypeDuration && r == TypeNumber:
+			return TypeDuration, nil
+		}
+		return mismatch()
+	}
+	return 0, c.errorf(n, "未知的运算符 %q", n.op)
+}
+
+// checkPath 按指标字段表解析路径，并检查索引用法
+func (c *checker) checkPath(n *pathExpr) (Type, error) {
+	cur := root
+	prefix := ""
+	n.fields = make([]*field, 0, len(n.steps))
+	for _, s := range n.steps {
+		if cur.typ != typeObject {
+			return 0, c.errorf(n, "%s 是指标值，没有字段 %q", prefix, s.name)
+		}
+		f, ok := cur.children[s.name]
+		if !ok {
+			if prefix == "" {
+				return 0, c.errorf(n, "未知的指标 %q，可用: %s", s.name, cur.names())
+			}
+			return 0, c.errorf(n, "%s 没有字段 %q，可用: %s", prefix, s.name, cur.names())
+		}
+		name := joinPath(prefix, s.name)
+		switch {
+		case s.key != nil && !f.indexed:
+			return 0, c.errorf(n, "%s 不支持按名称索引", name)
+		case s.key == nil && f.mustIndex:
+			return 0, c.errorf(n, "%s 需要指定名称，如 %s[\"name\"]", name, name)
+		}
+		if s.key != nil {
+			name += fmt.Sprintf("[%q]", *s.key)
+		}
+		n.fields = append(n.fields, f)
+		cur, prefix = f, name
+	}
+	if cur.typ == typeObject {
+		return 0, c.errorf(n, "%s 不是指标值，可用字段: %s", prefix, cur.names())
+	}
+	return cur.typ, nil
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func (n *literal) eval(*entity.SystemMetrics) (interface{}, error) { return n.value, nil }
+
+func (n *pathExpr) eval(m *entity.SystemMetrics) (interface{}, error) {
+	var obj interface{} = m
+	for i, f := range n.fields {
+		v, ok := f.get(obj, n.steps[i].key)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", n, ErrNoData)
+		}
+		obj = v
+	}
+	return obj, nil
+}
+
+func (n *pathExpr) String() string {
+	var b strings.Builder
+	for i, s := range n.steps {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.name)
+		if s.key != nil {
+			fmt.Fprintf(&b, "[%q]", *s.key)
+		}
+	}
+	return b.String()
+}
+
+func (n *unaryExpr) eval(m *entity.SystemMetrics) (interface{}, error) {
+	v, err := n.x.eval(m)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case bool:
+		return !v, nil
+	case float64:
+		return -v, nil
+	case time.Duration:
+		return -v, nil
+	}
+	return nil, fmt.Errorf("%q 不能用于 %T", n.op, v)
+}
+
+func (n *binaryExpr) eval(m *entity.SystemMetrics) (interface{}, error) {
+	if n.op == "&&" || n.op == "||" {
+		return n.evalLogical(m)
+	}
+	l, err := n.left.eval(m)
+	if err != nil {
+		return nil, err
+	}
+	r, err := n.right.eval(m)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return l == r, nil
+	case "!=":
+		return l != r, nil
+	case "<", "<=", ">", ">=":
+		return compare(n.op, l, r), nil
+	}
+	switch l := l.(type) {
+	case float64:
+		r := r.(float64)
+		switch n.op {
+		case "+":
+			return l + r, nil
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		case "/":
+			return l / r, nil
+		}
+	case time.Duration:
+		switch r := r.(type) {
+		case time.Duration:
+			switch n.op {
+			case "+":
+				return l + r, nil
+			case "-":
+				return l - r, nil
+			case "/":
+				return float64(l) / float64(r), nil
+			}
+		case float64:
+			switch n.op {
+			case "*":
+				return time.Duration(float64(l) * r), nil
+			case "/":
+				if r == 0 {
+					return nil, fmt.Errorf("时长除以 0")
+				}
+				return time.Duration(float64(l) / r), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%q 不能用于 %T 与 %T", n.op, l, r)
+}
+
+// evalLogical 与/或运算：一侧没有数据时由另一侧决定结果（如 false && 无数据 为 false），无法决定时返回错误
+func (n *binaryExpr) evalLogical(m *entity.SystemMetrics) (interface{}, error) {
+	decisive := n.op == "||" // || 任一侧为 true 即为 true，&& 任一侧为 false 即为 false
+	l, errL := n.left.eval(m)
+	if errL == nil && l.(bool) == decisive {
+		return decisive, nil
+	}
+	r, errR := n.right.eval(m)
+	if errR == nil && r.(bool) == decisive {
+		return decisive, nil
+	}
+	if errL != nil {
+		return nil, errL
+	}
+	if errR != nil {
+		return nil, errR
+	}
+	return !decisive, nil
+}
+
+func compare(op string, l, r interface{}) bool {
+	switch l := l.(type) {
+	case float64:
+		return ordered(op, l, r.(float64))
+	case time.Duration:
+		return ordered(op, l, r.(time.Duration))
+	case string:
+		return ordered(op, l, r.(string))
+	}
+	return false
+}
+
+// ordered 直接使用比较运算，NaN（如 0/0）参与的比较均不成立
+func ordered[T float64 | time.Duration | string](op string, a, b T) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
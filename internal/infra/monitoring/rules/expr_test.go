@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"GWatch/internal/entity"
+)
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string // 错误信息中应包含的片段
+	}{
+		// 词法与语法错误
+		{"", "表达式不能为空"},
+		{"cpu.percent >", "第 14 个字符处: 表达式不完整"},
+		{"(cpu.percent > 1", "第 17 个字符处: 缺少 \")\""},
+		{"cpu.percent > 1 1", "第 17 个字符处: 多余的 \"1\""},
+		{"cpu.percent # 1", "第 13 个字符处: 无法识别的字符"},
+		{`http["api`, "第 6 个字符处: 字符串缺少结束引号"},
+		{"cpu.percent > 2x", "第 15 个字符处: 无效的时长 \"2x\""},
+		{"cpu.percent > 1.2.3", "第 15 个字符处: 无效的数字 \"1.2.3\""},
+		{"http[api].accessible", "第 6 个字符处: \"[\" 后应为带引号的名称"},
+		{"cpu.percent > 1 && 指标", "第 20 个字符处: 无法识别的字符"},
+		// 比较运算不能连写，位置为第二个比较运算符
+		{"1 < cpu.percent < 3", "第 17 个字符处: 比较运算不能连写"},
+		{"cpu.percent > 1 == true", "第 17 个字符处: 比较运算不能连写"},
+		// 类型错误
+		{"cpu.percent > 2s", "第 13 个字符处: \">\" 两侧类型不匹配: number > duration，时长请带单位，如 2s、500ms"},
+		{`http["api"].response_time > 2`, "两侧类型不匹配: duration > number，时长请带单位"},
+		{"cpu.percent > 1 && 2", "第 17 个字符处: \"&&\" 两侧应为 bool"},
+		{"cpu", "第 1 个字符处: cpu 不是指标值"},
+		{"cpux.percent > 1", "第 1 个字符处: 未知的指标 \"cpux\""},
+		{"cpu.foo > 1", "cpu 没有字段 \"foo\""},
+		{`cpu["a"].percent > 1`, "cpu 不支持按名称索引"},
+		{`mysql["a"]["b"].up`, "已指定名称"},
+		// http 必须按名称索引
+		{"http.accessible", "http 需要指定名称，如 http[\"name\"]"},
+		{"http.response_time > 2s", "http 需要指定名称"},
+		// 条件必须为 bool
+		{"cpu.percent", "bool"},
+	}
+	for _, tt := range tests {
+		_, err := CompileCondition(tt.expr)
+		if err == nil {
+			t.Errorf("CompileCondition(%q) 应返回错误", tt.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("CompileCondition(%q) 错误 %q 不包含 %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestEvalLiterals(t *testing.T) {
+	tests := []struct {
+		expr string
+		want interface{}
+	}{
+		// 时长字面量
+		{"2s", 2 * time.Second},
+		{"1m30s", 90 * time.Second},
+		{"500ms + 1s", 1500 * time.Millisecond},
+		{"2s * 3", 6 * time.Second},
+		{"-2s", -2 * time.Second},
+		{"1m30s / 30s", 3.0},
+		{"1m30s > 90s", false},
+		{"1m30s >= 90s", true},
+		// 科学计数法为数字而非时长
+		{"1e5", 100000.0},
+		{"1.5e-3", 0.0015},
+		{"2E+3 > 1999", true},
+		{".5", 0.5},
+		{"10 / 4", 2.5},
+		{`"api" == "api"`, true},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.expr)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.expr, err)
+			continue
+		}
+		got, err := p.Eval(&entity.SystemMetrics{})
+		if err != nil {
+			t.Errorf("%q Eval: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q = %v (%T)，期望 %v (%T)", tt.expr, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestEvalMetrics(t *testing.T) {
+	m := &entity.SystemMetrics{
+		CPU: entity.CPUMetrics{Percent: 60},
+		HTTP: entity.HTTPMetrics{Interfaces: []entity.HTTPInterfaceMetrics{
+			{Name: "api", IsAccessible: true, StatusCode: 200, ResponseTime: 2 * time.Minute},
+			{Name: "web", Error: errors.New("timeout")},
+		}},
+		MySQL: []entity.MySQLMetrics{
+			{Name: "main", Connections: entity.ConnectionMetrics{ThreadsRunning: 12}},
+			{Name: "down", Error: errors.New("connection refused")},
+		},
+	}
+	tests := []struct {
+		expr   string
+		want   bool
+		noData bool // 期望返回 ErrNoData
+	}{
+		// 按名称索引
+		{`http["api"].accessible`, true, false},
+		{`http["api"].response_time > 1m30s`, true, false},
+		{`http["api"].response_time > 2m`, false, false},
+		{`http["api"].status_code == 200`, true, false},
+		{`http["web"].accessible`, false, false},
+		{`http["web"].status_code >= 500`, false, true},
+		{`http["missing"].accessible`, false, true},
+		// 不强制索引的集合不指定名称时取第一个实例
+		{`mysql.connections.threads_running > 10`, true, false},
+		{`mysql["main"].up`, true, false},
+		{`mysql["down"].up`, false, false},
+		{`mysql["down"].connections.threads_running > 10`, false, true},
+		// ErrNoData 在 && / || 中被另一侧的决定性结果短路
+		{`http["missing"].accessible || cpu.percent > 50`, true, false},
+		{`cpu.percent > 50 || http["missing"].accessible`, true, false},
+		{`http["missing"].accessible && cpu.percent > 80`, false, false},
+		{`cpu.percent > 80 && http["missing"].accessible`, false, false},
+		{`http["missing"].accessible && cpu.percent > 50`, false, true},
+		{`http["missing"].accessible || cpu.percent > 80`, false, true},
+		{`http["missing"].accessible || http["web"].status_code > 0`, false, true},
+		{`!http["missing"].accessible`, false, true},
+	}
+	for _, tt := range tests {
+		p, err := CompileCondition(tt.expr)
+		if err != nil {
+			t.Errorf("CompileCondition(%q): %v", tt.expr, err)
+			continue
+		}
+		got, err := p.Eval(m)
+		if tt.noData {
+			if !errors.Is(err, ErrNoData) {
+				t.Errorf("%q 应返回 ErrNoData，实际为 %v, %v", tt.expr, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q Eval: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q = %v，期望 %v", tt.expr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package rules 自定义告警规则：表达式的词法/语法分析、类型检查与按指标求值
+//
+// 表达式语法：
+//
+//	字面量    85、99.5、1e5、2s、1m30s（时长）、"api"（字符串）、true、false
+//	指标字段  cpu.percent、mysql.connections.usage、http["api"].response_time、disk.mounts["/data"].percent
+//	运算符    ! - * / + - < <= > >= == != && ||（优先级从高到低），括号改变优先级
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokDuration
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	num  float64
+	dur  time.Duration
+}
+
+// syntaxError 带位置（从 1 开始的字符序号）的表达式错误
+func syntaxError(src string, pos int, format string, args ...interface{}) error {
+	col := len([]rune(src[:pos])) + 1
+	return fmt.Errorf("第 %d 个字符处: %s", col, fmt.Sprintf(format, args...))
+}
+
+// twoCharOps 两个字符的运算符，需要先于单字符运算符匹配
+var twoCharOps = []string{"&&", "||", "==", "!=", "<=", ">="}
+
+// lex 将表达式切分为记号
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isDigit(c) || c == '.' && i+1 < len(src) && isDigit(src[i+1]):
+			// 数字（可用科学计数法，如 1e5、1.5e-3）或带单位的时长（如 2s、1m30s、500ms）
+			start := i
+			hasUnit := false
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.' || isLetter(src[i])) {
+				hasUnit = hasUnit || isLetter(src[i])
+				if (src[i] == 'e' || src[i] == 'E') && i+2 < len(src) && (src[i+1] == '+' || src[i+1] == '-') && isDigit(src[i+2]) {
+					i++ // 指数的符号
+				}
+				i++
+			}
+			text := src[start:i]
+			n, err := strconv.ParseFloat(text, 64)
+			if err == nil {
+				tokens = append(tokens, token{kind: tokNumber, text: text, pos: start, num: n})
+				continue
+			}
+			if hasUnit {
+				d, err := time.ParseDuration(text)
+				if err != nil {
+					return nil, syntaxError(src, start, "无效的时长 %q", text)
+				}
+				tokens = append(tokens, token{kind: tokDuration, text: text, pos: start, dur: d})
+				continue
+			}
+			return nil, syntaxError(src, start, "无效的数字 %q", text)
+		case c == '"':
+			start := i
+			i++
+			for i < len(src) && src[i] != '"' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(src) {
+				return nil, syntaxError(src, start, "字符串缺少结束引号")
+			}
+			i++
+			s, err := strconv.Unquote(src[start:i])
+			if err != nil {
+				return nil, syntaxError(src, start, "无效的字符串 %s", src[start:i])
+			}
+			tokens = append(tokens, token{kind: tokString, text: s, pos: start})
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(src) && (src[i] == '_' || isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			op := ""
+			for _, o := range twoCharOps {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" && strings.IndexByte("()[].!<>+-*/", c) >= 0 {
+				op = string(c)
+			}
+			if op == "" {
+				r := []rune(src[i:])[0]
+				return nil, syntaxError(src, i, "无法识别的字符 %q", r)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+// parser 递归下降解析，优先级从低到高：|| && 比较 加减 乘除 一元
+type parser struct {
+	src    string
+	tokens []token
+	i      int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, fmt.Errorf("表达式不能为空")
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorAt(t, "多余的 %q", t.text)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.i] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept 下一个记号是给定运算符之一时消费并返回
+func (p *parser) accept(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokOp {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.i++
+			return t, true
+		}
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		if t.kind == tokEOF {
+			return p.errorAt(t, "缺少 %q", op)
+		}
+		return p.errorAt(t, "应为 %q，实际为 %q", op, t.text)
+	}
+	return nil
+}
+
+func (p *parser) errorAt(t token, format string, args ...interface{}) error {
+	return syntaxError(p.src, t.pos, format, args...)
+}
+
+// binaryLevel 解析同一优先级的左结合二元运算
+func (p *parser) binaryLevel(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{pos: t.pos, op: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) or() (node, error) { return p.binaryLevel(p.and, "||") }
+
+func (p *parser) and() (node, error) { return p.binaryLevel(p.comparison, "&&") }
+
+// comparison 比较运算不可连写（如 1 < x < 3）
+func (p *parser) comparison() (node, error) {
+	left, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := p.accept("<", "<=", ">", ">=", "==", "!=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	if next, ok := p.accept("<", "<=", ">", ">=", "==", "!="); ok {
+		return nil, p.errorAt(next, "比较运算不能连写，请使用 && 组合")
+	}
+	return &binaryExpr{pos: t.pos, op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) additive() (node, error) { return p.binaryLevel(p.multiplicative, "+", "-") }
+
+func (p *parser) multiplicative() (node, error) { return p.binaryLevel(p.unary, "*", "/") }
+
+func (p *parser) unary() (node, error) {
+	if t, ok := p.accept("!", "-"); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{pos: t.pos, op: t.text, x: x}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &literal{pos: t.pos, typ: TypeNumber, value: t.num}, nil
+	case tokDuration:
+		return &literal{pos: t.pos, typ: TypeDuration, value: t.dur}, nil
+	case tokString:
+		return &literal{pos: t.pos, typ: TypeString, value: t.text}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return &literal{pos: t.pos, typ: TypeBool, value: t.text == "true"}, nil
+		}
+		return p.path(t)
+	case tokOp:
+		if t.text == "(" {
+			n, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		}
+		return nil, p.errorAt(t, "此处不能使用 %q", t.text)
+	default:
+		return nil, p.errorAt(t, "表达式不完整")
+	}
+}
+
+// path 指标字段路径：ident ( "." ident | "[" 字符串 "]" )*
+func (p *parser) path(first token) (node, error) {
+	n := &pathExpr{pos: first.pos, steps: []step{{name: first.text}}}
+	for {
+		if _, ok := p.accept("."); ok {
+			t := p.next()
+			if t.kind != tokIdent {
+				return nil, p.errorAt(t, "\".\" 后应为字段名")
+			}
+			n.steps = append(n.steps, step{name: t.text})
+			continue
+		}
+		if open, ok := p.accept("["); ok {
+			t := p.next()
+			if t.kind != tokString {
+				return nil, p.errorAt(t, "\"[\" 后应为带引号的名称，如 %s[\"name\"]", n.steps[len(n.steps)-1].name)
+			}
+			last := &n.steps[len(n.steps)-1]
+			if last.key != nil {
+				return nil, p.errorAt(open, "%s 已指定名称", last.name)
+			}
+			key := t.text
+			last.key = &key
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return n, nil
+	}
+}
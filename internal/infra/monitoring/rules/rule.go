@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"GWatch/internal/entity"
+	"GWatch/internal/infra/monitoring/templates"
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Rule 编译后的告警规则
+type Rule struct {
+	entity.AlertRule
+	condition *Program
+	message   *template.Template
+}
+
+// CompileCondition 编译规则表达式，结果必须为 bool
+func CompileCondition(expr string) (*Program, error) {
+	p, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	if p.Type() != TypeBool {
+		return nil, fmt.Errorf("表达式结果应为 bool，实际为 %s", p.Type())
+	}
+	return p, nil
+}
+
+// ParseMessage 解析告警消息模板，可使用 templates.Funcs 中的辅助函数
+func ParseMessage(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templates.Funcs()).Parse(text)
+}
+
+// CompileRule 编译规则的表达式与消息模板
+func CompileRule(r entity.AlertRule) (*Rule, error) {
+	condition, err := CompileCondition(r.Expr)
+	if err != nil {
+		return nil, fmt.Errorf("expr: %v", err)
+	}
+	var message *template.Template
+	if strings.TrimSpace(r.Message) != "" {
+		if message, err = ParseMessage(r.Name, r.Message); err != nil {
+			return nil, fmt.Errorf("message: %v", err)
+		}
+	}
+	return &Rule{AlertRule: r, condition: condition, message: message}, nil
+}
+
+// Match 表达式是否成立；引用的指标没有数据时返回 ErrNoData
+func (r *Rule) Match(m *entity.SystemMetrics) (bool, error) {
+	v, err := r.condition.Eval(m)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// Message 渲染告警消息，未配置模板或渲染失败时使用 "自定义规则 [名称]: 表达式"
+func (r *Rule) Message(m *entity.SystemMetrics) (string, error) {
+	fallback := fmt.Sprintf("%s: %s", entity.RuleAlert.Describe(r.Name), r.Expr)
+	if r.message == nil {
+		return fallback, nil
+	}
+	var buf bytes.Buffer
+	if err := r.message.Execute(&buf, MessageData{Name: r.Name, Severity: r.Severity, Expr: r.Expr, For: r.For, Metrics: m}); err != nil {
+		return fallback, err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// MessageData 告警消息模板的数据
+type MessageData struct {
+	Name     string
+	Severity string
+	Expr     string
+	For      time.Duration
+	Metrics  *entity.SystemMetrics
+}
+
+// Value 在模板中按表达式取当前值并格式化，如 {{.Value "mysql.connections.usage"}}
+func (d MessageData) Value(expr string) (string, error) {
+	p, err := Compile(expr)
+	if err != nil {
+		return "", err
+	}
+	v, err := p.Eval(d.Metrics)
+	if err != nil {
+		return "", err
+	}
+	return FormatValue(v), nil
+}
+
+// FormatValue 格式化表达式的值：整数不带小数，小数保留两位，时长取整到毫秒
+func FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.2f", v)
+	case time.Duration:
+		return v.Round(time.Millisecond).String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"GWatch/internal/entity"
+	"sort"
+	"strings"
+	"time"
+)
+
+// field 表达式可引用的指标字段：对象字段有子字段，叶子字段有值类型
+// get 由上一级对象取得本级对象或值，key 为 ["name"] 索引；返回 false 表示没有数据
+type field struct {
+	typ       Type
+	children  map[string]*field
+	indexed   bool // 可按名称索引，不指定名称时取第一个实例，如 mysql 与 mysql["primary"]
+	mustIndex bool // 必须按名称索引，如 http["api"]
+	get       func(parent interface{}, key *string) (interface{}, bool)
+}
+
+// names 可用的子字段名称，用于错误提示
+func (f *field) names() string {
+	names := make([]string, 0, len(f.children))
+	for name, child := range f.children {
+		if child.mustIndex {
+			name += `["name"]`
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// object 对象字段，available 为 false 时（如采集失败）其下所有指标都没有数据
+func object[P, C any](get func(P) (C, bool), children map[string]*field) *field {
+	return &field{typ: typeObject, children: children, get: func(p interface{}, _ *string) (interface{}, bool) {
+		return get(p.(P))
+	}}
+}
+
+// collection 多实例集合字段，按名称索引，未指定名称时取第一个
+func collection[P, C any](mustIndex bool, list func(P) []C, name func(*C) string, children map[string]*field) *field {
+	return &field{typ: typeObject, children: children, indexed: true, mustIndex: mustIndex,
+		get: func(p interface{}, key *string) (interface{}, bool) {
+			items := list(p.(P))
+			for i := range items {
+				if key == nil || name(&items[i]) == *key {
+					return &items[i], true
+				}
+			}
+			return nil, false
+		}}
+}
+
+// leaf 叶子字段，available 为 false 时没有数据
+func leaf[P any, V float64 | bool | string | time.Duration](typ Type, get func(P) (V, bool)) *field {
+	return &field{typ: typ, get: func(p interface{}, _ *string) (interface{}, bool) {
+		return get(p.(P))
+	}}
+}
+
+func number[P any](get func(P) float64) *field {
+	return leaf(TypeNumber, func(p P) (float64, bool) { return get(p), true })
+}
+
+func boolean[P any](get func(P) bool) *field {
+	return leaf(TypeBool, func(p P) (bool, bool) { return get(p), true })
+}
+
+// root 全部可引用的指标，与 entity.SystemMetrics 对应
+var root = &field{typ: typeObject, children: map[string]*field{
+	"cpu": object(func(m *entity.SystemMetrics) (*entity.CPUMetrics, bool) { return &m.CPU, m.CPU.Error == nil }, map[string]*field{
+		"percent": number(func(c *entity.CPUMetrics) float64 { return c.Percent }),
+	}),
+	"memory": object(func(m *entity.SystemMetrics) (*entity.MemoryMetrics, bool) { return &m.Memory, m.Memory.Error == nil }, map[string]*field{
+		"percent":  number(func(c *entity.MemoryMetrics) float64 { return c.Percent }),
+		"used_mb":  number(func(c *entity.MemoryMetrics) float64 { return float64(c.UsedMB) }),
+		"total_mb": number(func(c *entity.MemoryMetrics) float64 { return float64(c.TotalMB) }),
+	}),
+	"disk": object(func(m *entity.SystemMetrics) (*entity.DiskMetrics, bool) { return &m.Disk, m.Disk.Error == nil }, map[string]*field{
+		"percent":    number(func(d *entity.DiskMetrics) float64 { return d.Percent }),
+		"used_gb":    number(func(d *entity.DiskMetrics) float64 { return float64(d.UsedGB) }),
+		"total_gb":   number(func(d *entity.DiskMetrics) float64 { return float64(d.TotalGB) }),
+		"read_kbps":  number(func(d *entity.DiskMetrics) float64 { return d.ReadKBps }),
+		"write_kbps": number(func(d *entity.DiskMetrics) float64 { return d.WriteKBps }),
+		"mounts": collection(true, func(d *entity.DiskMetrics) []entity.MountMetrics { return d.Mounts },
+			func(m *entity.MountMetrics) string { return m.Mount }, map[string]*field{
+				"percent":        number(func(m *entity.MountMetrics) float64 { return m.Percent }),
+				"used_gb":        number(func(m *entity.MountMetrics) float64 { return float64(m.UsedGB) }),
+				"total_gb":       number(func(m *entity.MountMetrics) float64 { return float64(m.TotalGB) }),
+				"inodes_percent": number(func(m *entity.MountMetrics) float64 { return m.InodesPercent }),
+			}),
+	}),
+	"network": object(func(m *entity.SystemMetrics) (*entity.NetworkMetrics, bool) {
+		return &m.Network, m.Network.Error == nil
+	}, map[string]*field{
+		"download_kbps": number(func(n *entity.NetworkMetrics) float64 { return n.DownloadKBps }),
+		"upload_kbps":   number(func(n *entity.NetworkMetrics) float64 { return n.UploadKBps }),
+		"interfaces": collection(true, func(n *entity.NetworkMetrics) []entity.InterfaceMetrics { return n.Interfaces },
+			func(i *entity.InterfaceMetrics) string { return i.Name }, map[string]*field{
+				"recv_kbps":  number(func(i *entity.InterfaceMetrics) float64 { return i.RecvKBps }),
+				"sent_kbps":  number(func(i *entity.InterfaceMetrics) float64 { return i.SentKBps }),
+				"error_rate": number(func(i *entity.InterfaceMetrics) float64 { return i.ErrorRate() }),
+				"drop_rate":  number(func(i *entity.InterfaceMetrics) float64 { return i.DropRate() }),
+				"bandwidth": leaf(TypeNumber, func(i *entity.InterfaceMetrics) (float64, bool) {
+					return i.BandwidthUsed, i.SpeedMbps > 0
+				}),
+			}),
+	}),
+	"redis": collection(false, func(m *entity.SystemMetrics) []entity.RedisMetrics { return m.Redis },
+		func(r *entity.RedisMetrics) string { return r.Name }, map[string]*field{
+			"up": boolean(func(r *entity.RedisMetrics) bool { return r.ConnectionError == nil }),
+			"clients": leaf(TypeNumber, func(r *entity.RedisMetrics) (float64, bool) {
+				return float64(r.ClientCount), r.ConnectionError == nil
+			}),
+		}),
+	"mysql": collection(false, func(m *entity.SystemMetrics) []entity.MySQLMetrics { return m.MySQL },
+		func(r *entity.MySQLMetrics) string { return r.Name }, map[string]*field{
+			"up":                      boolean(func(r *entity.MySQLMetrics) bool { return r.Error == nil }),
+			"qps":                     mysqlNumber(func(r *entity.MySQLMetrics) float64 { return r.QueryPerformance.QueriesPerSecond }),
+			"tps":                     mysqlNumber(func(r *entity.MySQLMetrics) float64 { return r.QueryPerformance.TransactionsPerSecond }),
+			"slow_queries":            mysqlNumber(func(r *entity.MySQLMetrics) float64 { return float64(r.QueryPerformance.SlowQueries) }),
+			"slow_queries_per_minute": mysqlNumber(func(r *entity.MySQLMetrics) float64 { return r.QueryPerformance.SlowQueriesPerMinute }),
+			"connections": object(mysqlPart(func(r *entity.MySQLMetrics) *entity.ConnectionMetrics { return &r.Connections }), map[string]*field{
+				"usage":             number(func(c *entity.ConnectionMetrics) float64 { return c.ConnectionUsage }),
+				"threads_connected": number(func(c *entity.ConnectionMetrics) float64 { return float64(c.ThreadsConnected) }),
+				"threads_running":   number(func(c *entity.ConnectionMetrics) float64 { return float64(c.ThreadsRunning) }),
+				"max":               number(func(c *entity.ConnectionMetrics) float64 { return float64(c.MaxConnections) }),
+			}),
+			"buffer_pool": object(mysqlPart(func(r *entity.MySQLMetrics) *entity.BufferPoolMetrics { return &r.BufferPool }), map[string]*field{
+				"hit_rate": number(func(b *entity.BufferPoolMetrics) float64 { return b.HitRate }),
+				"usage":    number(func(b *entity.BufferPoolMetrics) float64 { return b.Usage }),
+			}),
+			"replication": object(mysqlPart(func(r *entity.MySQLMetrics) *entity.ReplicationMetrics { return r.Replication }), map[string]*field{
+				"delay":       number(func(r *entity.ReplicationMetrics) float64 { return float64(r.SecondsBehindMaster) }),
+				"io_running":  boolean(func(r *entity.ReplicationMetrics) bool { return r.SlaveIORunning == "Yes" }),
+				"sql_running": boolean(func(r *entity.ReplicationMetrics) bool { return r.SlaveSQLRunning == "Yes" }),
+			}),
+			"locks": object(mysqlPart(func(r *entity.MySQLMetrics) *entity.LockMetrics { return &r.Locks }), map[string]*field{
+				"deadlocks":                 number(func(l *entity.LockMetrics) float64 { return float64(l.Deadlocks) }),
+				"row_lock_waits":            number(func(l *entity.LockMetrics) float64 { return float64(l.RowLockWaits) }),
+				"deadlocks_per_hour":        number(func(l *entity.LockMetrics) float64 { return l.DeadlocksPerHour }),
+				"row_lock_waits_per_minute": number(func(l *entity.LockMetrics) float64 { return l.RowLockWaitsPerMinute }),
+			}),
+			"transactions": object(mysqlPart(func(r *entity.MySQLMetrics) *entity.TransactionMetrics { return &r.Transactions }), map[string]*field{
+				"uncommitted": number(func(t *entity.TransactionMetrics) float64 { return float64(t.UncommittedTransactions) }),
+			}),
+		}),
+	"http": collection(true, func(m *entity.SystemMetrics) []entity.HTTPInterfaceMetrics { return m.HTTP.Interfaces },
+		func(h *entity.HTTPInterfaceMetrics) string { return h.Name }, map[string]*field{
+			"accessible": boolean(func(h *entity.HTTPInterfaceMetrics) bool { return h.IsAccessible && h.Error == nil }),
+			"status_code": leaf(TypeNumber, func(h *entity.HTTPInterfaceMetrics) (float64, bool) {
+				return float64(h.StatusCode), h.StatusCode > 0
+			}),
+			"response_time": leaf(TypeDuration, func(h *entity.HTTPInterfaceMetrics) (time.Duration, bool) {
+				return h.ResponseTime, h.StatusCode > 0
+			}),
+		}),
+}}
+
+// mysqlPart MySQL 实例的指标分组，连接失败或分组不存在（如主库没有复制状态）时没有数据
+func mysqlPart[C any](get func(*entity.MySQLMetrics) *C) func(*entity.MySQLMetrics) (*C, bool) {
+	return func(r *entity.MySQLMetrics) (*C, bool) {
+		c := get(r)
+		return c, r.Error == nil && c != nil
+	}
+}
+
+// mysqlNumber MySQL 实例的数值指标，连接失败时没有数据
+func mysqlNumber(get func(*entity.MySQLMetrics) float64) *field {
+	return leaf(TypeNumber, func(r *entity.MySQLMetrics) (float64, bool) { return get(r), r.Error == nil })
+}
@@ -0,0 +1,193 @@
+package monitoring
+
+import (
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"GWatch/internal/utils"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSilencer 静默判断：配置中的维护窗口 + silences.file 中的临时静默，文件修改后自动重新读取
+type FileSilencer struct {
+	mu       sync.Mutex
+	path     string
+	modTime  time.Time
+	silences []entity.Silence
+}
+
+// NewSilencer 创建静默判断器
+func NewSilencer() monitoring.Silencer {
+	return &FileSilencer{}
+}
+
+// Match 返回 at 时刻命中告警的第一个静默，维护窗口优先
+func (s *FileSilencer) Match(cfg *entity.Config, alertType entity.AlertType, target string, at time.Time) *entity.Silence {
+	active := s.Active(cfg, at)
+	if len(active) == 0 {
+		return nil
+	}
+	hosts := utils.HostIdentities()
+	for i := range active {
+		if silenceMatches(active[i].SilenceMatchers, alertType, target, hosts) {
+			return &active[i]
+		}
+	}
+	return nil
+}
+
+// Active 返回 at 时刻生效的维护窗口与临时静默
+func (s *FileSilencer) Active(cfg *entity.Config, at time.Time) []entity.Silence {
+	var result []entity.Silence
+	for _, w := range cfg.Silences.MaintenanceWindows {
+		if silence, ok := maintenanceSilence(w, at); ok {
+			result = append(result, silence)
+		}
+	}
+	for _, silence := range s.load(cfg.Silences.File) {
+		if silence.ActiveAt(at) {
+			result = append(result, silence)
+		}
+	}
+	return result
+}
+
+// load 读取临时静默文件，文件未变化时使用缓存；读取失败时保留上次的结果
+func (s *FileSilencer) load(path string) []entity.Silence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			s.path, s.modTime, s.silences = path, time.Time{}, nil
+		}
+		return s.silences
+	}
+	if path == s.path && info.ModTime().Equal(s.modTime) {
+		return s.silences
+	}
+	silences, err := LoadSilences(path)
+	if err != nil {
+		log.Printf("[ERROR] 读取静默文件 %s 失败: %v", path, err)
+		return s.silences
+	}
+	s.path, s.modTime, s.silences = path, info.ModTime(), silences
+	return silences
+}
+
+// maintenanceSilence 维护窗口在 at 时刻生效时，返回本次窗口对应的静默
+func maintenanceSilence(w entity.MaintenanceWindow, at time.Time) (entity.Silence, bool) {
+	schedule, err := utils.ParseCron(w.Schedule)
+	if err != nil {
+		return entity.Silence{}, false
+	}
+	loc := time.Local
+	if w.Timezone != "" {
+		if l, err := time.LoadLocation(w.Timezone); err == nil {
+			loc = l
+		}
+	}
+	start, ok := schedule.Prev(at.In(loc), w.Duration)
+	if !ok || at.Sub(start) >= w.Duration {
+		return entity.Silence{}, false
+	}
+	start = start.In(at.Location())
+	return entity.Silence{
+		ID:              w.Name,
+		SilenceMatchers: w.SilenceMatchers,
+		StartsAt:        start,
+		EndsAt:          start.Add(w.Duration),
+		Comment:         w.Comment,
+		CreatedBy:       entity.SilenceCreatedByMaintenance,
+	}, true
+}
+
+// silenceMatches 判断告警是否满足静默的全部匹配条件，条件为空表示不限
+func silenceMatches(m entity.SilenceMatchers, alertType entity.AlertType, target string, hosts []string) bool {
+	if len(m.AlertTypes) > 0 && !utils.MatchAny(m.AlertTypes, string(alertType)) {
+		return false
+	}
+	if len(m.Targets) > 0 && !utils.MatchAny(m.Targets, target) {
+		return false
+	}
+	if len(m.Hosts) > 0 {
+		for _, h := range hosts {
+			if utils.MatchAny(m.Hosts, h) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// LoadSilences 读取临时静默文件，文件不存在时返回空列表
+func LoadSilences(path string) ([]entity.Silence, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var silences []entity.Silence
+	if err := json.Unmarshal(data, &silences); err != nil {
+		return nil, fmt.Errorf("解析静默文件失败: %v", err)
+	}
+	return silences, nil
+}
+
+// AddSilence 新建临时静默并写入文件，同时清理已过期的静默；返回生成的静默 ID
+func AddSilence(path string, silence entity.Silence) (string, error) {
+	silences, err := LoadSilences(path)
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	silence.ID = hex.EncodeToString(id)
+
+	now := time.Now()
+	kept := silences[:0]
+	for _, s := range silences {
+		if now.Before(s.EndsAt) {
+			kept = append(kept, s)
+		}
+	}
+	if err := writeJSONFile(path, append(kept, silence)); err != nil {
+		return "", fmt.Errorf("保存静默文件失败: %v", err)
+	}
+	return silence.ID, nil
+}
+
+// ExpireSilence 立即结束指定 ID 的临时静默
+func ExpireSilence(path, id string) error {
+	silences, err := LoadSilences(path)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range silences {
+		if silences[i].ID != id {
+			continue
+		}
+		if !now.Before(silences[i].EndsAt) {
+			return fmt.Errorf("静默 %s 已过期", id)
+		}
+		silences[i].EndsAt = now
+		if err := writeJSONFile(path, silences); err != nil {
+			return fmt.Errorf("保存静默文件失败: %v", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("未找到静默 %s", id)
+}
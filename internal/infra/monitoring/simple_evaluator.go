@@ -18,84 +18,83 @@ func (s *SimpleEvaluator) Evaluate(cfg *entity.Config, metrics *entity.SystemMet
         if metrics.CPU.Error != nil {
             decisions = append(decisions, domainMonitor.Decision{Type: entity.CPUErr})
         } else if metrics.CPU.Percent > cfg.HostMonitoring.CPUThreshold {
-            decisions = append(decisions, domainMonitor.Decision{Type: entity.CPUHigh})
+            decisions = append(decisions, domainMonitor.Decision{Type: entity.CPUHigh, Value: metrics.CPU.Percent,
+                Severity: entity.SeverityAbove(metrics.CPU.Percent, cfg.HostMonitoring.CPUCriticalThreshold)})
         }
 
         if metrics.Memory.Error != nil {
             decisions = append(decisions, domainMonitor.Decision{Type: entity.MemErr})
         } else if metrics.Memory.Percent > cfg.HostMonitoring.MemoryThreshold {
-            decisions = append(decisions, domainMonitor.Decision{Type: entity.MemHigh})
+            decisions = append(decisions, domainMonitor.Decision{Type: entity.MemHigh, Value: metrics.Memory.Percent,
+                Severity: entity.SeverityAbove(metrics.Memory.Percent, cfg.HostMonitoring.MemoryCriticalThreshold)})
         }
 
         if metrics.Disk.Error != nil {
             decisions = append(decisions, domainMonitor.Decision{Type: entity.DiskErr})
-        } else if metrics.Disk.Percent > cfg.HostMonitoring.DiskThreshold {
-            decisions = append(decisions, domainMonitor.Decision{Type: entity.DiskHigh})
+        } else if len(metrics.Disk.Mounts) == 0 && metrics.Disk.Percent > cfg.HostMonitoring.DiskThreshold {
+            // 未取得挂载点明细时退回根分区判断
+            decisions = append(decisions, domainMonitor.Decision{Type: entity.DiskHigh, Value: metrics.Disk.Percent,
+                Severity: entity.SeverityAbove(metrics.Disk.Percent, cfg.HostMonitoring.DiskCriticalThreshold)})
+        }
+        // 挂载点评估：各挂载点使用各自的阈值，告警对象为挂载点路径
+        for _, mount := range metrics.Disk.Mounts {
+            if mount.Percent > cfg.HostMonitoring.MountThreshold(mount.Mount) {
+                decisions = append(decisions, domainMonitor.Decision{Type: entity.DiskHigh, Target: mount.Mount, Value: mount.Percent,
+                    Severity: entity.SeverityAbove(mount.Percent, cfg.HostMonitoring.MountCriticalThreshold(mount.Mount))})
+            }
+            if d := cfg.HostMonitoring.Disks; d != nil && d.InodeThreshold > 0 && mount.InodesTotal > 0 && mount.InodesPercent > d.InodeThreshold {
+                decisions = append(decisions, domainMonitor.Decision{Type: entity.DiskInodeHigh, Target: mount.Mount, Value: mount.InodesPercent,
+                    Severity: entity.SeverityAbove(mount.InodesPercent, d.InodeCriticalThreshold)})
+            }
         }
 
         if metrics.Network.Error != nil {
             decisions = append(decisions, domainMonitor.Decision{Type: entity.NetworkErr})
         }
+        // 网卡评估：带宽饱和、错误包、丢包，告警对象为网卡名；阈值为 0 表示不检查
+        if n := cfg.HostMonitoring.Network; n != nil {
+            for _, itf := range metrics.Network.Interfaces {
+                if n.BandwidthThreshold > 0 && itf.SpeedMbps > 0 && itf.BandwidthUsed > n.BandwidthThreshold {
+                    decisions = append(decisions, domainMonitor.Decision{Type: entity.NetBandwidthHigh, Target: itf.Name, Value: itf.BandwidthUsed,
+                        Severity: entity.SeverityAbove(itf.BandwidthUsed, n.BandwidthCriticalThreshold)})
+                }
+                if n.ErrorRateThreshold > 0 && itf.ErrorRate() > n.ErrorRateThreshold {
+                    decisions = append(decisions, domainMonitor.Decision{Type: entity.NetErrorsHigh, Target: itf.Name, Value: itf.ErrorRate()})
+                }
+                if n.DropRateThreshold > 0 && itf.DropRate() > n.DropRateThreshold {
+                    decisions = append(decisions, domainMonitor.Decision{Type: entity.NetDropsHigh, Target: itf.Name, Value: itf.DropRate()})
+                }
+            }
+        }
     }
 
     // 应用层类监控评估：只有当app_monitoring配置存在且启用时才评估
     if cfg != nil && cfg.AppMonitoring != nil && cfg.AppMonitoring.Enabled {
-        // Redis监控评估：只有当Redis配置存在且启用时才评估
-        if cfg.AppMonitoring.Redis != nil && cfg.AppMonitoring.Redis.Enabled {
-            if metrics.Redis.ConnectionError != nil {
-                decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisErr})
+        // Redis监控评估：按实例分别使用各自的阈值，只评估配置中仍启用的实例
+        for _, redisMetrics := range metrics.Redis {
+            redisCfg, ok := cfg.AppMonitoring.RedisTarget(redisMetrics.Name)
+            if !ok {
+                continue
+            }
+            if redisMetrics.ConnectionError != nil {
+                decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisErr, Target: redisMetrics.Name})
             } else {
-                if metrics.Redis.ClientCount < cfg.AppMonitoring.Redis.MinClients {
-                    decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisLow})
-                } else if metrics.Redis.ClientCount > cfg.AppMonitoring.Redis.MaxClients {
-                    decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisHigh})
+                if redisMetrics.ClientCount < redisCfg.MinClients {
+                    decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisLow, Target: redisMetrics.Name, Value: float64(redisMetrics.ClientCount)})
+                } else if redisMetrics.ClientCount > redisCfg.MaxClients {
+                    decisions = append(decisions, domainMonitor.Decision{Type: entity.RedisHigh, Target: redisMetrics.Name, Value: float64(redisMetrics.ClientCount),
+                        Severity: entity.SeverityAbove(float64(redisMetrics.ClientCount), float64(redisCfg.MaxClientsCritical))})
                 }
             }
         }
 
-		// MySQL监控评估：只有当MySQL配置存在且启用时才评估
-		if cfg.AppMonitoring != nil && cfg.AppMonitoring.MySQL != nil && cfg.AppMonitoring.MySQL.Enabled {
-			if metrics.MySQL.Error != nil {
-				decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLConnErr})
-			} else {
-				// 连接数评估 - 只有当连接数大于0时才评估
-				if metrics.MySQL.Connections.ThreadsConnected > 0 && 
-					metrics.MySQL.Connections.ConnectionUsage > cfg.AppMonitoring.MySQL.Thresholds.MaxConnectionsUsageWarning {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLConnHigh})
-				}
-
-				// 活跃线程数评估 - 只有当活跃线程数大于0时才评估
-				if metrics.MySQL.Connections.ThreadsRunning > cfg.AppMonitoring.MySQL.Thresholds.ThreadsRunningWarning {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLThreadsHigh})
-				}
-
-				// 慢查询评估 - 只有当慢查询数大于0时才评估
-				if metrics.MySQL.QueryPerformance.SlowQueries > 0 && 
-					metrics.MySQL.QueryPerformance.SlowQueries > cfg.AppMonitoring.MySQL.Thresholds.SlowQueriesRateWarning {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLSlowQuery})
-				}
-
-				// Buffer Pool命中率评估 - 只有当命中率数据有效时才评估
-				if metrics.MySQL.BufferPool.HitRate > 0 && 
-					metrics.MySQL.BufferPool.HitRate < cfg.AppMonitoring.MySQL.Thresholds.BufferPoolHitRateWarning {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLBufferLow})
-				}
-
-				// 复制延迟评估 - 只有当复制配置启用且延迟大于0时才评估
-				if cfg.AppMonitoring.MySQL.Replication != nil && 
-					cfg.AppMonitoring.MySQL.Replication.Enabled &&
-					metrics.MySQL.Replication != nil && 
-					metrics.MySQL.Replication.SecondsBehindMaster > 0 &&
-					metrics.MySQL.Replication.SecondsBehindMaster > cfg.AppMonitoring.MySQL.Thresholds.ReplicationDelayWarningSeconds {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLReplDelay})
-				}
-
-				// 死锁评估 - 只有当死锁数大于0时才评估
-				if metrics.MySQL.Locks.Deadlocks > 0 && 
-					metrics.MySQL.Locks.Deadlocks > cfg.AppMonitoring.MySQL.Thresholds.DeadlocksPerHourWarning {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.MySQLDeadlock})
-				}
+		// MySQL监控评估：按实例分别使用各自的阈值，只评估配置中仍启用的实例
+		for _, mysqlMetrics := range metrics.MySQL {
+			mysqlCfg, ok := cfg.AppMonitoring.MySQLTarget(mysqlMetrics.Name)
+			if !ok {
+				continue
 			}
+			decisions = append(decisions, evaluateMySQL(&mysqlCfg, &mysqlMetrics)...)
 		}
 
 		// HTTP接口监控评估：只有当HTTP配置存在且启用时才评估
@@ -128,13 +127,81 @@ func (s *SimpleEvaluator) Evaluate(cfg *entity.Config, metrics *entity.SystemMet
 
 				// 如果异常数量超过配置阈值，触发告警
 				if errorCount > cfg.AppMonitoring.HTTP.ErrorThreshold {
-					decisions = append(decisions, domainMonitor.Decision{Type: entity.HTTPErr})
+					decisions = append(decisions, domainMonitor.Decision{Type: entity.HTTPErr, Value: float64(errorCount),
+						Severity: entity.SeverityAbove(float64(errorCount), float64(cfg.AppMonitoring.HTTP.CriticalErrorThreshold))})
 				}
 			}
 		}
     }
 
+    // 未区分级别的告警（含监控失败类）均为 warning
+    for i := range decisions {
+        if decisions[i].Severity == "" {
+            decisions[i].Severity = entity.SeverityWarning
+        }
+    }
     return decisions, nil
 }
 
-
+// evaluateMySQL 按单个 MySQL 实例的阈值判断，决策的 Target 为实例名称
+func evaluateMySQL(mysqlCfg *entity.MySQLMonitoringConfig, m *entity.MySQLMetrics) []domainMonitor.Decision {
+	var decisions []domainMonitor.Decision
+	add := func(t entity.AlertType, value float64, critical float64) {
+		decisions = append(decisions, domainMonitor.Decision{Type: t, Target: m.Name, Value: value, Severity: entity.SeverityAbove(value, critical)})
+	}
+	if m.Error != nil {
+		add(entity.MySQLConnErr, 0, 0)
+		return decisions
+	}
+	t := mysqlCfg.Thresholds
+
+	// 连接数评估 - 只有当连接数大于0时才评估
+	if m.Connections.ThreadsConnected > 0 &&
+		m.Connections.ConnectionUsage > t.MaxConnectionsUsageWarning {
+		add(entity.MySQLConnHigh, m.Connections.ConnectionUsage, t.MaxConnectionsUsageCritical)
+	}
+
+	// 活跃线程数评估 - 只有当活跃线程数大于0时才评估
+	if m.Connections.ThreadsRunning > t.ThreadsRunningWarning {
+		add(entity.MySQLThreadsHigh, float64(m.Connections.ThreadsRunning), float64(t.ThreadsRunningCritical))
+	}
+
+	// 慢查询评估 - 按最近一分钟新增的慢查询数，只有当有新增时才评估
+	if m.QueryPerformance.SlowQueriesPerMinute > 0 &&
+		m.QueryPerformance.SlowQueriesPerMinute > float64(t.SlowQueriesRateWarning) {
+		add(entity.MySQLSlowQuery, m.QueryPerformance.SlowQueriesPerMinute, 0)
+	}
+
+	// QPS评估 - 按每秒查询数，未配置阈值时不评估
+	if t.QPSWarning > 0 &&
+		m.QueryPerformance.QueriesPerSecond > float64(t.QPSWarning) {
+		add(entity.MySQLQPSHigh, m.QueryPerformance.QueriesPerSecond, float64(t.QPSCritical))
+	}
+
+	// 行锁等待评估 - 按最近一分钟新增的行锁等待次数，未配置阈值时不评估
+	if t.RowLockWaitsPerMinuteWarning > 0 &&
+		m.Locks.RowLockWaitsPerMinute > float64(t.RowLockWaitsPerMinuteWarning) {
+		add(entity.MySQLLockWait, m.Locks.RowLockWaitsPerMinute, 0)
+	}
+
+	// Buffer Pool命中率评估 - 只有当命中率数据有效时才评估
+	if m.BufferPool.HitRate > 0 &&
+		m.BufferPool.HitRate < t.BufferPoolHitRateWarning {
+		add(entity.MySQLBufferLow, m.BufferPool.HitRate, 0)
+	}
+
+	// 复制延迟评估 - 只有从库且延迟大于0时才评估
+	if mysqlCfg.IsReplica() &&
+		m.Replication != nil &&
+		m.Replication.SecondsBehindMaster > 0 &&
+		m.Replication.SecondsBehindMaster > mysqlCfg.ReplicationDelayThreshold() {
+		add(entity.MySQLReplDelay, float64(m.Replication.SecondsBehindMaster), float64(t.ReplicationDelayCriticalSeconds))
+	}
+
+	// 死锁评估 - 按最近一小时新增的死锁次数，只有当有新增时才评估
+	if m.Locks.DeadlocksPerHour > 0 &&
+		m.Locks.DeadlocksPerHour > float64(t.DeadlocksPerHourWarning) {
+		add(entity.MySQLDeadlock, m.Locks.DeadlocksPerHour, 0)
+	}
+	return decisions
+}
@@ -0,0 +1,233 @@
+package templates
+
+import (
+	"GWatch/internal/entity"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+// Funcs 模板可用的辅助函数
+//
+//	percent 93.2            -> "93.20%"
+//	bytes 1536              -> "1.50 KB"
+//	kbps 12.3               -> "12.30 KB/s"
+//	datetime .Timestamp     -> "2006-01-02 15:04:05"
+//	ms .ResponseTime        -> 毫秒（float64）
+//	round .Duration         -> 取整到秒
+//	label "Redis" .Name     -> "Redis [cache]"
+//	status 91 80            -> "[异常]"（超过阈值）/"[正常]"
+//	statusBelow 90 95       -> "[异常]"（低于阈值）/"[正常]"
+//	severity .Severity      -> "🔴 [严重]"/"🟠 [警告]"/"🔵 [提示]"
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"percent":  func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+		"bytes":    formatBytes,
+		"kbps":     func(v float64) string { return fmt.Sprintf("%.2f KB/s", v) },
+		"datetime": func(t time.Time) string { return t.Format(time.DateTime) },
+		"ms":       func(d time.Duration) float64 { return float64(d.Nanoseconds()) / 1e6 },
+		"round":    func(d time.Duration) time.Duration { return d.Round(time.Second) },
+		"label":    entity.TargetLabel,
+		"add":      func(a, b int) int { return a + b },
+		"sub":      func(a, b int) int { return a - b },
+		"severity": SeverityLabel,
+
+		"status":          Status,
+		"statusBelow":     StatusBelow,
+		"mountStatus":     MountStatus,
+		"interfaceStatus": InterfaceStatus,
+		"redisStatus":     RedisStatus,
+		"mysqlConnStatus": func(usage, threshold float64) string { return thresholdText(usage > threshold, "[连接数过高]") },
+		"mysqlBufferStatus": func(hitRate, threshold float64) string {
+			return thresholdText(hitRate < threshold, "[命中率过低]")
+		},
+		"mysqlReplStatus": func(delay, threshold int) string {
+			return thresholdText(delay > 0 && delay > threshold, "[复制延迟]")
+		},
+		"mysqlConfig": mysqlConfig,
+		"bandwidth":   bandwidthText,
+		"httpOK":      HTTPStatusOK,
+		"accessible":  accessibleInterfaces,
+
+		"firstFailed":     firstFailed,
+		"firstAccessible": firstAccessible,
+		"errorTypeText":   errorTypeText,
+		"hostTitle":       hostTitle,
+	}
+}
+
+// severityEmoji 各告警级别的标识颜色，不支持字体颜色的渠道也能区分级别
+var severityEmoji = map[string]string{
+	entity.SeverityInfo:     "🔵",
+	entity.SeverityWarning:  "🟠",
+	entity.SeverityCritical: "🔴",
+}
+
+// SeverityLabel 告警级别标签，如 "🔴 [严重]"；未知级别返回空
+func SeverityLabel(severity string) string {
+	text, ok := entity.SeverityText[severity]
+	if !ok {
+		return ""
+	}
+	return severityEmoji[severity] + " [" + text + "]"
+}
+
+// Status 超过阈值为异常
+func Status(value, threshold float64) string {
+	return thresholdText(value > threshold, "[异常]")
+}
+
+// StatusBelow 低于阈值为异常（如命中率）
+func StatusBelow(value, threshold float64) string {
+	return thresholdText(value < threshold, "[异常]")
+}
+
+func thresholdText(abnormal bool, text string) string {
+	if abnormal {
+		return text
+	}
+	return "[正常]"
+}
+
+// MountStatus 按挂载点自身的阈值判断空间与 inode 状态
+func MountStatus(mount entity.MountMetrics, h *entity.HostMonitoringConfig) string {
+	if mount.Percent > h.MountThreshold(mount.Mount) {
+		return "[异常]"
+	}
+	if h.Disks != nil && h.Disks.InodeThreshold > 0 && mount.InodesTotal > 0 && mount.InodesPercent > h.Disks.InodeThreshold {
+		return "[inode异常]"
+	}
+	return "[正常]"
+}
+
+// InterfaceStatus 按网卡阈值判断带宽、错误包、丢包状态，阈值为 0 的项不判断
+func InterfaceStatus(itf entity.InterfaceMetrics, n *entity.NetworkInterfaceConfig) string {
+	if n == nil {
+		return "[正常]"
+	}
+	if n.BandwidthThreshold > 0 && itf.SpeedMbps > 0 && itf.BandwidthUsed > n.BandwidthThreshold {
+		return "[带宽过高]"
+	}
+	if n.ErrorRateThreshold > 0 && itf.ErrorRate() > n.ErrorRateThreshold {
+		return "[错误包过多]"
+	}
+	if n.DropRateThreshold > 0 && itf.DropRate() > n.DropRateThreshold {
+		return "[丢包过多]"
+	}
+	return "[正常]"
+}
+
+// RedisStatus 按实例自身的阈值判断连接数状态
+func RedisStatus(r entity.RedisMetrics, cfg *entity.Config) string {
+	if redisCfg, ok := cfg.AppMonitoring.RedisTarget(r.Name); ok {
+		if r.ClientCount < redisCfg.MinClients {
+			return "[连接数过低]"
+		}
+		if r.ClientCount > redisCfg.MaxClients {
+			return "[连接数过高]"
+		}
+	}
+	return "[正常]"
+}
+
+// HTTPStatusOK 状态码是否在允许范围内（未配置 allowed_codes 时只允许 200）
+func HTTPStatusOK(itf entity.HTTPInterfaceMetrics) bool {
+	if len(itf.AllowedCodes) == 0 {
+		return itf.StatusCode == 200
+	}
+	for _, code := range itf.AllowedCodes {
+		if itf.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// mysqlConfig 按实例名称取 MySQL 配置（含阈值），未找到时返回零值
+func mysqlConfig(cfg *entity.Config, name string) *entity.MySQLMonitoringConfig {
+	mysqlCfg, _ := cfg.AppMonitoring.MySQLTarget(name)
+	return &mysqlCfg
+}
+
+// bandwidthText 链路速率已知时附带带宽使用率
+func bandwidthText(itf entity.InterfaceMetrics) string {
+	if itf.SpeedMbps <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" | 带宽 %.2f%% (%d Mbps)", itf.BandwidthUsed, itf.SpeedMbps)
+}
+
+// accessibleInterfaces 可访问且无错误的 HTTP 接口
+func accessibleInterfaces(list []entity.HTTPInterfaceMetrics) []entity.HTTPInterfaceMetrics {
+	var res []entity.HTTPInterfaceMetrics
+	for _, itf := range list {
+		if itf.Error == nil && itf.IsAccessible {
+			res = append(res, itf)
+		}
+	}
+	return res
+}
+
+// firstFailed 第一个不可访问的设备状态接口，没有则为 nil
+func firstFailed(list []entity.TickerInterfaceMetrics) *entity.TickerInterfaceMetrics {
+	for i := range list {
+		if !list[i].IsAccessible {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+// firstAccessible 第一个可访问的设备状态接口，没有则为 nil
+func firstAccessible(list []entity.TickerInterfaceMetrics) *entity.TickerInterfaceMetrics {
+	for i := range list {
+		if list[i].IsAccessible {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+func errorTypeText(t entity.ErrorType) string {
+	switch t {
+	case entity.ErrorTypeToken:
+		return "Token 已过期"
+	case entity.ErrorTypeUnauthorized:
+		return "认证失败（401/403）"
+	case entity.ErrorTypeNetwork:
+		return "网络连接失败"
+	case entity.ErrorTypeServer:
+		return "服务端异常（5xx）"
+	default:
+		return "未知错误"
+	}
+}
+
+// hostTitle 聚合报告中主机的标题：client 上传的 title，其次主机名，再次 IP，最后使用 server 配置的 title
+func hostTitle(c *entity.ClientMonitorData, fallback string) string {
+	title := c.Title
+	if title == "" {
+		title = c.HostName
+	}
+	if title == "" || title == "unknown-host" {
+		title = c.HostIP
+	}
+	if title == "" {
+		title = fallback
+	}
+	return title
+}
+
+// formatBytes 以 1024 为进制格式化字节数，如 "1.50 GB"
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
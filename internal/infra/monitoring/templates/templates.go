@@ -0,0 +1,173 @@
+// Package templates 通知消息模板：基于 Go text/template，内置默认模板，可通过配置 templates 指定自定义模板文件
+package templates
+
+import (
+	"GWatch/internal/domain/monitoring"
+	"GWatch/internal/entity"
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// 模板名称，与 default 目录下的文件名对应
+const (
+	Alert           = "alert"            // 实时告警，数据为 AlertData
+	Recovery        = "recovery"         // 告警恢复，数据为 RecoveryData
+	TickerReport    = "ticker_report"    // ticker 设备状态报告，数据为 TickerReportData
+	ScheduledReport = "scheduled_report" // 全局定时推送（server 聚合）报告，数据为 ScheduledReportData
+)
+
+//go:embed default/*.tmpl
+var defaultFS embed.FS
+
+// Host 本机主机信息，获取失败时 Error 非空
+type Host struct {
+	IP       string
+	Hostname string
+	Error    error
+}
+
+// AlertData 实时告警模板数据
+type AlertData struct {
+	Title   string
+	Config  *entity.Config
+	Metrics *entity.SystemMetrics
+	Alerts  []monitoring.TriggeredAlert
+	Host    Host
+}
+
+// RecoveryData 告警恢复模板数据
+type RecoveryData struct {
+	Title      string
+	Config     *entity.Config
+	Metrics    *entity.SystemMetrics
+	Recoveries []monitoring.Recovery
+	Host       Host
+}
+
+// TickerReportData ticker 报告模板数据
+type TickerReportData struct {
+	Title   string
+	Config  *entity.Config
+	Ticker  *entity.TickerMetrics
+	Metrics *entity.SystemMetrics
+	Host    Host
+}
+
+// ScheduledReportData 全局定时推送报告模板数据，Timestamp 为最后一个客户端数据的时间
+type ScheduledReportData struct {
+	Title     string
+	Clients   []*entity.ClientMonitorData
+	Timestamp time.Time
+}
+
+// cachedTemplate 已解析的自定义模板，文件修改后重新解析
+type cachedTemplate struct {
+	modTime time.Time
+	tmpl    *template.Template
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cachedTemplate{}
+)
+
+// Parse 解析模板文本，注册全部辅助函数
+func Parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(Funcs()).Parse(text)
+}
+
+// Check 读取并解析自定义模板文件，用于配置校验
+func Check(name, path string) error {
+	_, err := load(name, path)
+	return err
+}
+
+// Render 渲染模板：path 为空使用内置模板；自定义模板读取、解析或执行失败时记录日志并回退到内置模板
+func Render(name, path string, data any) string {
+	if path != "" {
+		tmpl, err := load(name, path)
+		if err == nil {
+			var buf bytes.Buffer
+			if err = tmpl.Execute(&buf, data); err == nil {
+				return buf.String()
+			}
+		}
+		log.Printf("[ERROR] 渲染自定义模板 %s 失败，使用内置模板: %v", path, err)
+	}
+
+	tmpl, err := defaultTemplate(name)
+	if err != nil {
+		log.Printf("[ERROR] 加载内置模板 %s 失败: %v", name, err)
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		log.Printf("[ERROR] 渲染内置模板 %s 失败: %v", name, err)
+	}
+	return buf.String()
+}
+
+// load 加载自定义模板文件，按修改时间缓存
+func load(name, path string) (*template.Template, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板文件失败: %w", err)
+	}
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if c, ok := cache[path]; ok && c.modTime.Equal(info.ModTime()) {
+		return c.tmpl, nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板文件失败: %w", err)
+	}
+	tmpl, err := Parse(name, string(text))
+	if err != nil {
+		return nil, fmt.Errorf("解析模板失败: %w", err)
+	}
+	cache[path] = cachedTemplate{modTime: info.ModTime(), tmpl: tmpl}
+	return tmpl, nil
+}
+
+var (
+	defaultsOnce sync.Once
+	defaults     map[string]*template.Template
+	defaultsErr  error
+)
+
+// defaultTemplate 内置模板，首次使用时解析
+func defaultTemplate(name string) (*template.Template, error) {
+	defaultsOnce.Do(func() {
+		defaults = map[string]*template.Template{}
+		for _, n := range []string{Alert, Recovery, TickerReport, ScheduledReport} {
+			text, err := Default(n)
+			if err == nil {
+				defaults[n], err = Parse(n, text)
+			}
+			if err != nil {
+				defaultsErr = fmt.Errorf("%s: %w", n, err)
+				return
+			}
+		}
+	})
+	if defaultsErr != nil {
+		return nil, defaultsErr
+	}
+	return defaults[name], nil
+}
+
+// Default 返回内置模板文本，可作为编写自定义模板的起点
+func Default(name string) (string, error) {
+	text, err := defaultFS.ReadFile("default/" + name + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("未知的模板 %q", name)
+	}
+	return string(text), nil
+}
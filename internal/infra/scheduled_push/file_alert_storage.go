@@ -79,26 +79,14 @@ func (f *FileAlertStorage) GetScheduledPushAlerts(startTime, endTime time.Time)
 
 	var allAlerts []*entity.ScheduledPushAlertRecord
 	
-	// 遍历时间范围内的所有日期
-	for d := startTime; d.Before(endTime) || d.Equal(endTime); d = d.AddDate(0, 0, 1) {
-		// 生成该日期的目录路径
-		year := d.Year() % 100
-		month := int(d.Month())
-		day := d.Day()
-		dirPath := fmt.Sprintf("logs/%02d/%02d/%02d", year, month, day)
-		
-		// 读取该目录下的所有日志文件
-		entries, err := os.ReadDir(dirPath)
+	// 按 alert_log_path_template 遍历时间范围内每一天的日志文件
+	day := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location())
+	for ; !day.After(endTime); day = day.AddDate(0, 0, 1) {
+		files, err := filepath.Glob(f.dayPattern(day))
 		if err != nil {
-			continue // 目录不存在或无法读取，跳过
+			return nil, fmt.Errorf("alert_log_path_template 无效: %v", err)
 		}
-		
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasPrefix(entry.Name(), "scheduled_push-") {
-				continue
-			}
-			
-			filePath := filepath.Join(dirPath, entry.Name())
+		for _, filePath := range files {
 			alerts, err := f.readAlertsFromFile(filePath, startTime, endTime)
 			if err != nil {
 				continue // 文件读取失败，跳过
@@ -154,65 +142,17 @@ func (f *FileAlertStorage) CleanupOldAlerts() error {
 
 	cutoffTime := time.Now().AddDate(0, 0, -f.config.RetentionDays)
 	
-	// 遍历所有可能的日期目录
-	baseDir := "logs"
-	entries, err := os.ReadDir(baseDir)
+	// 按 alert_log_path_template 匹配全部日志文件，删除最后修改时间早于保留期限的
+	files, err := filepath.Glob(f.expandTemplate("*", "*", "*", "*", "*", "scheduled_push-*.log"))
 	if err != nil {
-		return err
+		return fmt.Errorf("alert_log_path_template 无效: %v", err)
 	}
-	
-	for _, yearEntry := range entries {
-		if !yearEntry.IsDir() {
-			continue
-		}
-		
-		yearPath := filepath.Join(baseDir, yearEntry.Name())
-		monthEntries, err := os.ReadDir(yearPath)
-		if err != nil {
+	for _, filePath := range files {
+		info, err := os.Stat(filePath)
+		if err != nil || info.IsDir() || !info.ModTime().Before(cutoffTime) {
 			continue
 		}
-		
-		for _, monthEntry := range monthEntries {
-			if !monthEntry.IsDir() {
-				continue
-			}
-			
-			monthPath := filepath.Join(yearPath, monthEntry.Name())
-			dayEntries, err := os.ReadDir(monthPath)
-			if err != nil {
-				continue
-			}
-			
-			for _, dayEntry := range dayEntries {
-				if !dayEntry.IsDir() {
-					continue
-				}
-				
-				dayPath := filepath.Join(monthPath, dayEntry.Name())
-				
-				// 解析日期
-				var year, month, day int
-				if _, err := fmt.Sscanf(dayEntry.Name(), "%02d", &day); err != nil {
-					continue
-				}
-				if _, err := fmt.Sscanf(filepath.Base(monthPath), "%02d", &month); err != nil {
-					continue
-				}
-				if _, err := fmt.Sscanf(filepath.Base(yearPath), "%02d", &year); err != nil {
-					continue
-				}
-				
-				// 年份需要加上2000
-				fullYear := 2000 + year
-				
-				// 检查日期是否过期
-				dirDate := time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, time.Local)
-				if dirDate.Before(cutoffTime) {
-					// 删除整个目录
-					os.RemoveAll(dayPath)
-				}
-			}
-		}
+		os.Remove(filePath)
 	}
 
 	return nil
@@ -243,15 +183,35 @@ func (f *FileAlertStorage) generateFilePath(timestamp time.Time, pushTime string
 	month := int(timestamp.Month())
 	day := timestamp.Day()
 	
-	// 替换模板中的占位符
+	return f.expandTemplate(
+		fmt.Sprintf("%02d", year),
+		fmt.Sprintf("%02d", month),
+		fmt.Sprintf("%02d", day),
+		fmt.Sprintf("%02d", hour),
+		fmt.Sprintf("%02d", minute),
+		fileName,
+	)
+}
+
+// dayPattern 某一天全部日志文件的通配路径（时、分与文件名为 *）
+func (f *FileAlertStorage) dayPattern(day time.Time) string {
+	return f.expandTemplate(
+		fmt.Sprintf("%02d", day.Year()%100),
+		fmt.Sprintf("%02d", int(day.Month())),
+		fmt.Sprintf("%02d", day.Day()),
+		"*", "*", "scheduled_push-*.log",
+	)
+}
+
+// expandTemplate 替换 alert_log_path_template 中的占位符：%y 年（两位）、%m 月、%d 日、%H 时、%M 分、%s 文件名
+func (f *FileAlertStorage) expandTemplate(year, month, day, hour, minute, fileName string) string {
 	path := f.config.AlertLogPathTemplate
-	path = strings.ReplaceAll(path, "%y", fmt.Sprintf("%02d", year))
-	path = strings.ReplaceAll(path, "%m", fmt.Sprintf("%02d", month))
-	path = strings.ReplaceAll(path, "%d", fmt.Sprintf("%02d", day))
-	path = strings.ReplaceAll(path, "%H", fmt.Sprintf("%02d", hour))
-	path = strings.ReplaceAll(path, "%M", fmt.Sprintf("%02d", minute))
+	path = strings.ReplaceAll(path, "%y", year)
+	path = strings.ReplaceAll(path, "%m", month)
+	path = strings.ReplaceAll(path, "%d", day)
+	path = strings.ReplaceAll(path, "%H", hour)
+	path = strings.ReplaceAll(path, "%M", minute)
 	path = strings.ReplaceAll(path, "%s", fileName)
-	
 	return path
 }
 
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule 解析后的 5 字段 cron 表达式：分 时 日 月 周
+// 每个字段支持 *、数字、范围 a-b、步长 */n 或 a-b/n 以及逗号分隔的列表；周 0 和 7 均表示周日
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool // 日/周字段为 * 时不参与匹配，两者都受限时满足其一即可（与标准 cron 一致）
+}
+
+// cronField 单个字段的取值范围
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{{"分钟", 0, 59}, {"小时", 0, 23}, {"日", 1, 31}, {"月", 1, 12}, {"周", 0, 7}}
+
+// ParseCron 解析 5 字段 cron 表达式，如 "0 2 * * *"（每天 2:00）、"30 1 * * 6,0"（周六、周日 1:30）
+func ParseCron(expr string) (*CronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("cron 表达式应为 5 个字段（分 时 日 月 周），当前为 %q", expr)
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron 表达式 %q: %v", expr, err)
+		}
+		bits[i] = b
+	}
+	// 周 7 与周 0 相同
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &CronSchedule{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domAny: parts[2] == "*", dowAny: parts[4] == "*",
+	}, nil
+}
+
+// parseCronField 将单个字段解析为取值位图
+func parseCronField(s string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s字段步长 %q 无效", f.name, stepStr)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(a)
+			hi = lo
+			if isRange {
+				hi, err2 = strconv.Atoi(b)
+			} else if hasStep {
+				hi = f.max
+			}
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("%s字段 %q 无效", f.name, item)
+			}
+			if lo < f.min || hi > f.max || lo > hi {
+				return 0, fmt.Errorf("%s字段 %q 超出范围 %d-%d", f.name, item, f.min, f.max)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Matches 判断时间 t（按其自身时区）所在的分钟是否命中表达式
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 || s.hour&(1<<uint(t.Hour())) == 0 || s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domOK := s.dom&(1<<uint(t.Day())) != 0
+	dowOK := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowOK
+	case s.dowAny:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
+
+// Prev 返回不晚于 t、且距 t 不超过 within 的最近一次触发时间（精确到分钟），没有则返回 false
+func (s *CronSchedule) Prev(t time.Time, within time.Duration) (time.Time, bool) {
+	start := t.Truncate(time.Minute)
+	for at := start; t.Sub(at) <= within; at = at.Add(-time.Minute) {
+		if s.Matches(at) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
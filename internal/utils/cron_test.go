@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string // 错误信息中应包含的片段
+	}{
+		{"0 2 * *", "5 个字段"},
+		{"0 2 * * * *", "5 个字段"},
+		{"*/0 * * * *", "分钟字段步长"},
+		{"*/x * * * *", "分钟字段步长"},
+		{"60 * * * *", "超出范围 0-59"},
+		{"0 24 * * *", "超出范围 0-23"},
+		{"0 0 0 * *", "超出范围 1-31"},
+		{"0 0 * 13 *", "超出范围 1-12"},
+		{"0 0 * * 8", "超出范围 0-7"},
+		{"0 17-9 * * *", "超出范围"},
+		{"a * * * *", "分钟字段 \"a\" 无效"},
+		{"0 1-x * * *", "小时字段 \"1-x\" 无效"},
+	}
+	for _, tt := range tests {
+		_, err := ParseCron(tt.expr)
+		if err == nil {
+			t.Errorf("ParseCron(%q) 应返回错误", tt.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseCron(%q) 错误 %q 不包含 %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	// 2026-06-01 为周一，2026-06-07 为周日，2026-07-01 为周三
+	at := func(s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		expr string
+		at   string
+		want bool
+	}{
+		// */n 步长
+		{"*/15 * * * *", "2026-06-01 10:00", true},
+		{"*/15 * * * *", "2026-06-01 10:45", true},
+		{"*/15 * * * *", "2026-06-01 10:20", false},
+		// a/n 从 a 开始到字段最大值
+		{"5/20 * * * *", "2026-06-01 10:05", true},
+		{"5/20 * * * *", "2026-06-01 10:45", true},
+		{"5/20 * * * *", "2026-06-01 10:00", false},
+		{"5/20 * * * *", "2026-06-01 10:25", true},
+		// 范围与带步长的范围
+		{"10-20/5 * * * *", "2026-06-01 10:15", true},
+		{"10-20/5 * * * *", "2026-06-01 10:25", false},
+		{"0 9-17 * * 1-5", "2026-06-01 09:00", true},
+		{"0 9-17 * * 1-5", "2026-06-01 17:00", true},
+		{"0 9-17 * * 1-5", "2026-06-01 18:00", false},
+		{"0 9-17 * * 1-5", "2026-06-06 09:00", false},
+		// 列表
+		{"30 1 * * 6,0", "2026-06-06 01:30", true},
+		{"30 1 * * 6,0", "2026-06-07 01:30", true},
+		{"30 1 * * 6,0", "2026-06-08 01:30", false},
+		// 周 7 与周 0 均为周日
+		{"0 2 * * 7", "2026-06-07 02:00", true},
+		{"0 2 * * 0", "2026-06-07 02:00", true},
+		{"0 2 * * 5-7", "2026-06-07 02:00", true},
+		{"0 2 * * 7", "2026-06-01 02:00", false},
+		// 日与周都受限时满足其一即可
+		{"0 0 1 * 1", "2026-07-01 00:00", true},
+		{"0 0 1 * 1", "2026-06-08 00:00", true},
+		{"0 0 1 * 1", "2026-06-02 00:00", false},
+		// 只限制其一时只看受限的字段
+		{"0 0 1 * *", "2026-07-01 00:00", true},
+		{"0 0 1 * *", "2026-06-08 00:00", false},
+		{"0 0 * * 1", "2026-07-01 00:00", false},
+		// 月
+		{"0 0 * 6 *", "2026-06-02 00:00", true},
+		{"0 0 * 6 *", "2026-07-01 00:00", false},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := s.Matches(at(tt.at)); got != tt.want {
+			t.Errorf("%q Matches(%s) = %v, 期望 %v", tt.expr, tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	cst := time.FixedZone("CST", 8*3600)
+	ist := time.FixedZone("IST", 5*3600+1800)
+	tests := []struct {
+		name   string
+		expr   string
+		t      time.Time
+		within time.Duration
+		want   time.Time // 零值表示没有
+	}{
+		{"窗口内", "0 2 * * *", time.Date(2026, 6, 1, 3, 59, 30, 0, time.UTC), 2 * time.Hour, time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)},
+		{"窗口结束时刻", "0 2 * * *", time.Date(2026, 6, 1, 4, 0, 0, 0, time.UTC), 2 * time.Hour, time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)},
+		{"窗口刚结束", "0 2 * * *", time.Date(2026, 6, 1, 4, 0, 1, 0, time.UTC), 2 * time.Hour, time.Time{}},
+		{"触发时刻本身", "0 2 * * *", time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC), 0, time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)},
+		{"跨天", "50 23 * * *", time.Date(2026, 6, 2, 0, 10, 0, 0, time.UTC), time.Hour, time.Date(2026, 6, 1, 23, 50, 0, 0, time.UTC)},
+		// 按 t 自身的时区匹配：UTC 17:40 为北京时间次日 01:40
+		{"非本地时区", "30 1 * * *", time.Date(2026, 6, 1, 17, 40, 0, 0, time.UTC).In(cst), time.Hour, time.Date(2026, 6, 2, 1, 30, 0, 0, cst)},
+		{"同一时刻按 UTC 不命中", "30 1 * * *", time.Date(2026, 6, 1, 17, 40, 0, 0, time.UTC), time.Hour, time.Time{}},
+		{"半小时时区", "0 9 * * *", time.Date(2026, 6, 1, 9, 20, 45, 0, ist), time.Hour, time.Date(2026, 6, 1, 9, 0, 0, 0, ist)},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: ParseCron(%q): %v", tt.name, tt.expr, err)
+		}
+		got, ok := s.Prev(tt.t, tt.within)
+		if ok != !tt.want.IsZero() || !got.Equal(tt.want) {
+			t.Errorf("%s: Prev(%v, %v) = %v, %v，期望 %v", tt.name, tt.t, tt.within, got, ok, tt.want)
+		}
+	}
+}
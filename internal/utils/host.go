@@ -4,6 +4,7 @@ package utils
 import (
 	"fmt"
 	"net"
+	"os"
 	"strings"
 )
 
@@ -53,3 +54,15 @@ func GetHostInfo() (ip, hostname string, err error) {
 	
 	return ip, hostname, nil
 }
+
+// HostIdentities 本机的主机名与IP，用于匹配告警路由、静默的 hosts 条件
+func HostIdentities() []string {
+	var ids []string
+	if name, err := os.Hostname(); err == nil {
+		ids = append(ids, name)
+	}
+	if ip, err := GetLocalIP(); err == nil {
+		ids = append(ids, ip)
+	}
+	return ids
+}
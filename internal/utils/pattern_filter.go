@@ -0,0 +1,21 @@
+package utils
+
+import "path"
+
+// MatchAny 判断名称是否匹配任意一个通配模式（path.Match 语法，如 "veth*"、"/var/lib/*"）
+func MatchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PatternSelected 按 include/exclude 通配模式过滤：命中 exclude 排除；include 为空时全部保留，否则只保留命中 include 的
+func PatternSelected(include, exclude []string, name string) bool {
+	if MatchAny(exclude, name) {
+		return false
+	}
+	return len(include) == 0 || MatchAny(include, name)
+}